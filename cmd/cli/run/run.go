@@ -8,6 +8,7 @@ import (
 
 	"github.com/apiqube/cli/internal/core/io"
 	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/runner/assert"
 	"github.com/apiqube/cli/internal/core/runner/context"
 	"github.com/apiqube/cli/internal/core/runner/executor"
 	"github.com/apiqube/cli/internal/core/runner/hooks"
@@ -74,8 +75,14 @@ var Cmd = &cobra.Command{
 			}
 		}
 
+		baseCtx := cmd.Context()
+		if opts.updateSnapshots {
+			cli.Info("Response snapshots will be rewritten")
+			baseCtx = assert.WithUpdateSnapshots(baseCtx)
+		}
+
 		ctxBuilder := context.NewCtxBuilder().
-			WithContext(cmd.Context()).
+			WithContext(baseCtx).
 			WithManifests(loadedManifests...)
 
 		registry := executor.NewDefaultExecutorRegistry()
@@ -106,6 +113,8 @@ func init() {
 	Cmd.Flags().BoolP("output", "o", false, "Make output after generating")
 	Cmd.Flags().String("output-path", "", "Output path to save the plan (default: current directory)")
 	Cmd.Flags().String("output-format", "yaml", "Output format (yaml|json)")
+
+	Cmd.Flags().Bool("update-snapshots", false, "Rewrite stored response snapshots with actual responses")
 }
 
 type options struct {
@@ -120,6 +129,8 @@ type options struct {
 	outputPath   string
 	outputFormat string
 
+	updateSnapshots bool
+
 	flagsSet map[string]bool
 }
 
@@ -163,6 +174,10 @@ func parseOptions(cmd *cobra.Command) (*options, error) {
 		opts.outputFormat, _ = cmd.Flags().GetString("output-format")
 	}
 
+	if markFlag("update-snapshots") {
+		opts.updateSnapshots, _ = cmd.Flags().GetBool("update-snapshots")
+	}
+
 	exclusiveFlags := []string{"names", "namespace", "ids", "hashes", "file"}
 
 	var usedFlags []string
@@ -98,6 +98,7 @@ func processFile(filePath string, manifestsSet map[string]struct{}) (new []manif
 		}
 
 		if existingManifest != nil {
+			existingManifest.GetMeta().SetSource(filePath)
 			if _, exists := manifestsSet[existingManifest.GetID()]; !exists {
 				manifestsSet[existingManifest.GetID()] = struct{}{}
 				cachedManifests = append(cachedManifests, existingManifest)
@@ -114,6 +115,7 @@ func processFile(filePath string, manifestsSet map[string]struct{}) (new []manif
 		meta.SetVersion(1)
 		meta.SetCreatedAt(now)
 		meta.SetUpdatedAt(now)
+		meta.SetSource(filePath)
 
 		manifestsSet[manifestID] = struct{}{}
 		newManifests = append(newManifests, m)
@@ -68,6 +68,9 @@ type Meta interface {
 
 	GetLastApplied() time.Time
 	SetLastApplied(lastApplied time.Time)
+
+	GetSource() string
+	SetSource(source string)
 }
 
 type Defaultable interface {
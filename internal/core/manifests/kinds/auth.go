@@ -0,0 +1,38 @@
+package kinds
+
+// Auth describes credentials requests are sent with, only one provider may be set,
+// values may contain templates like {{ Env(API_TOKEN) }} so secrets are kept out of manifests
+type Auth struct {
+	Basic  *BasicAuth  `yaml:"basic,omitempty" json:"basic,omitempty" validate:"omitempty,excluded_with=Bearer APIKey OAuth2"`
+	Bearer *BearerAuth `yaml:"bearer,omitempty" json:"bearer,omitempty" validate:"omitempty,excluded_with=Basic APIKey OAuth2"`
+	APIKey *APIKeyAuth `yaml:"apiKey,omitempty" json:"apiKey,omitempty" validate:"omitempty,excluded_with=Basic Bearer OAuth2"`
+	OAuth2 *OAuth2Auth `yaml:"oauth2,omitempty" json:"oauth2,omitempty" validate:"omitempty,excluded_with=Basic Bearer APIKey"`
+}
+
+type BasicAuth struct {
+	Username string `yaml:"username" json:"username" validate:"required"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+}
+
+type BearerAuth struct {
+	Token string `yaml:"token" json:"token" validate:"required"`
+}
+
+// APIKeyAuth sends the key as header or query parameter, header is used by default
+type APIKeyAuth struct {
+	Name  string `yaml:"name" json:"name" validate:"required"`
+	Value string `yaml:"value" json:"value" validate:"required"`
+	In    string `yaml:"in,omitempty" json:"in,omitempty" validate:"omitempty,oneof=header query"`
+}
+
+// OAuth2Auth fetches access token with client credentials or password grant,
+// the token is cached and fetched again or refreshed when it expires
+type OAuth2Auth struct {
+	TokenURL     string   `yaml:"tokenUrl" json:"tokenUrl" validate:"required"`
+	Grant        string   `yaml:"grant,omitempty" json:"grant,omitempty" validate:"omitempty,oneof=client_credentials password"`
+	ClientID     string   `yaml:"clientId" json:"clientId" validate:"required"`
+	ClientSecret string   `yaml:"clientSecret,omitempty" json:"clientSecret,omitempty"`
+	Username     string   `yaml:"username,omitempty" json:"username,omitempty" validate:"required_if=Grant password"`
+	Password     string   `yaml:"password,omitempty" json:"password,omitempty" validate:"required_if=Grant password"`
+	Scopes       []string `yaml:"scopes,omitempty" json:"scopes,omitempty" validate:"omitempty,max=50"`
+}
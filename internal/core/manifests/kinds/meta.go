@@ -43,6 +43,7 @@ type Meta struct {
 	UpdatedBy   string    `yaml:"-" json:"updatedBy"`
 	UsedBy      string    `yaml:"-" json:"usedBy"`
 	LastApplied time.Time `yaml:"-" json:"lastApplied"`
+	Source      string    `yaml:"-" json:"source,omitempty"`
 }
 
 func (m *Meta) GetHash() string {
@@ -122,3 +123,11 @@ func (m *Meta) GetLastApplied() time.Time {
 func (m *Meta) SetLastApplied(lastApplied time.Time) {
 	m.LastApplied = lastApplied
 }
+
+func (m *Meta) GetSource() string {
+	return m.Source
+}
+
+func (m *Meta) SetSource(source string) {
+	m.Source = source
+}
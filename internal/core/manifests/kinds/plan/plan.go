@@ -47,6 +47,26 @@ type Hooks struct {
 	OnFailure []hooks.Action `yaml:"onFailure,omitempty" json:"onFailure,omitempty" validate:"omitempty,dive"`
 }
 
+// Actions returns hook actions declared for the event, it is safe to call on nil hooks
+func (h *Hooks) Actions(event hooks.HookEvent) []hooks.Action {
+	if h == nil {
+		return nil
+	}
+
+	switch event {
+	case hooks.BeforeRun:
+		return h.BeforeRun
+	case hooks.AfterRun:
+		return h.AfterRun
+	case hooks.OnSuccess:
+		return h.OnSuccess
+	case hooks.OnFailure:
+		return h.OnFailure
+	default:
+		return nil
+	}
+}
+
 func (p *Plan) GetID() string {
 	return utils.FormManifestID(p.Namespace, p.Kind, p.Name)
 }
@@ -19,14 +19,27 @@ type Server struct {
 	kinds.BaseManifest `yaml:",inline" json:",inline" validate:"required"`
 
 	Spec struct {
-		BaseURL string            `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
-		Health  string            `yaml:"health" json:"health" validate:"omitempty,max=100"`
-		Headers map[string]string `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+		BaseURL   string            `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
+		Health    string            `yaml:"health" json:"health" validate:"omitempty,max=100"`
+		Headers   map[string]string `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+		Ready     *Readiness        `yaml:"readiness,omitempty" json:"readiness,omitempty" validate:"omitempty"`
+		Auth      *kinds.Auth       `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+		TLS       *kinds.TLS        `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+		Transport *kinds.Transport  `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 	} `yaml:"spec" json:"spec" validate:"required"`
 
 	Meta *kinds.Meta `yaml:"-" json:"meta"`
 }
 
+// Readiness configures how health path is polled before the server is considered ready,
+// without expected statuses any 2xx status is healthy
+type Readiness struct {
+	Interval time.Duration `yaml:"interval,omitempty" json:"interval,omitempty" validate:"omitempty,duration"`
+	Timeout  time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty" validate:"omitempty,duration"`
+	Status   []int         `yaml:"status,omitempty" json:"status,omitempty" validate:"omitempty,max=20,dive,min=100,max=599"`
+	Body     string        `yaml:"body,omitempty" json:"body,omitempty" validate:"omitempty,min=1"`
+}
+
 func (s *Server) GetID() string {
 	return utils.FormManifestID(s.Namespace, s.Kind, s.Name)
 }
@@ -0,0 +1,36 @@
+package kinds
+
+// Signing describes how requests are signed right before they are sent, only one signer may be set,
+// keys may contain templates like {{ Env(SIGNING_SECRET) }} so secrets are kept out of manifests
+type Signing struct {
+	HMAC   *HMACSigning   `yaml:"hmac,omitempty" json:"hmac,omitempty" validate:"omitempty,excluded_with=SigV4 Custom"`
+	SigV4  *SigV4Signing  `yaml:"sigv4,omitempty" json:"sigv4,omitempty" validate:"omitempty,excluded_with=HMAC Custom"`
+	Custom *CustomSigning `yaml:"custom,omitempty" json:"custom,omitempty" validate:"omitempty,excluded_with=HMAC SigV4"`
+}
+
+// HMACSigning signs method, path with query, listed headers and body hash with HMAC-SHA256,
+// signature is written to the header, X-Signature by default, timestamp names header the signing unix time is sent in
+type HMACSigning struct {
+	Secret    string   `yaml:"secret" json:"secret" validate:"required"`
+	Header    string   `yaml:"header,omitempty" json:"header,omitempty" validate:"omitempty,min=1"`
+	Headers   []string `yaml:"headers,omitempty" json:"headers,omitempty" validate:"omitempty,max=50,dive,min=1"`
+	Timestamp string   `yaml:"timestamp,omitempty" json:"timestamp,omitempty" validate:"omitempty,min=1"`
+	Encoding  string   `yaml:"encoding,omitempty" json:"encoding,omitempty" validate:"omitempty,oneof=hex base64"`
+}
+
+// SigV4Signing signs requests the way AWS Signature Version 4 does, path segments are encoded twice
+// in the canonical request except for s3 service, disableDoubleEncoding does the same for S3 compatible services
+type SigV4Signing struct {
+	AccessKey             string `yaml:"accessKey" json:"accessKey" validate:"required"`
+	SecretKey             string `yaml:"secretKey" json:"secretKey" validate:"required"`
+	SessionToken          string `yaml:"sessionToken,omitempty" json:"sessionToken,omitempty"`
+	Region                string `yaml:"region" json:"region" validate:"required"`
+	Service               string `yaml:"service" json:"service" validate:"required"`
+	DisableDoubleEncoding bool   `yaml:"disableDoubleEncoding,omitempty" json:"disableDoubleEncoding,omitempty" validate:"omitempty,boolean"`
+}
+
+// CustomSigning selects signer registered in the HTTP executor by name, params are passed to it with resolved templates
+type CustomSigning struct {
+	Name   string            `yaml:"name" json:"name" validate:"required,min=1"`
+	Params map[string]string `yaml:"params,omitempty" json:"params,omitempty" validate:"omitempty"`
+}
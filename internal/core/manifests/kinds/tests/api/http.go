@@ -21,8 +21,14 @@ type Http struct {
 	kinds.BaseManifest `yaml:",inline" json:",inline" validate:"required"`
 
 	Spec struct {
-		Target string     `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-		Cases  []HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+		Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+		Cases     []HttpCase       `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+		Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+		CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+		Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+		Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+		TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+		Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 	} `yaml:"spec" json:"spec" validate:"required"`
 
 	kinds.Dependencies `yaml:",inline" json:",inline" validate:"omitempty"`
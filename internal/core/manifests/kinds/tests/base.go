@@ -2,6 +2,8 @@ package tests
 
 import (
 	"time"
+
+	"github.com/apiqube/cli/internal/core/runner/hooks"
 )
 
 type HttpCase struct {
@@ -11,20 +13,107 @@ type HttpCase struct {
 	Endpoint string            `yaml:"endpoint,omitempty" json:"endpoint,omitempty" validate:"omitempty"`
 	Url      string            `yaml:"url,omitempty" json:"url,omitempty" validate:"omitempty,url"`
 	Headers  map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"  validate:"omitempty,min=1,max=100"`
+	Query    map[string]any    `yaml:"query,omitempty" json:"query,omitempty" validate:"omitempty,min=1,max=100"`
 	Body     map[string]any    `yaml:"body,omitempty" json:"body,omitempty" validate:"omitempty,min=1,max=100"`
+	BodyType string            `yaml:"bodyType,omitempty" json:"bodyType,omitempty" validate:"omitempty,oneof=json form multipart raw binary"`
+	Raw      string            `yaml:"raw,omitempty" json:"raw,omitempty" validate:"required_if=BodyType raw"`
+	File     string            `yaml:"file,omitempty" json:"file,omitempty" validate:"required_if=BodyType binary"`
+	Files    map[string]string `yaml:"files,omitempty" json:"files,omitempty" validate:"omitempty,min=1,max=20"`
 	Assert   []*Assert         `yaml:"assert,omitempty" json:"assert,omitempty" validate:"omitempty,min=1,max=50,dive"`
 	Save     *Save             `yaml:"save,omitempty" json:"save,omitempty" validate:"omitempty"`
 	Timeout  time.Duration     `yaml:"timeout,omitempty" json:"timeout,omitempty" validate:"omitempty,duration"`
 	Parallel bool              `yaml:"async,omitempty" json:"async,omitempty" validate:"omitempty,boolean"`
 	Details  []string          `yaml:"details,omitempty" json:"details,omitempty" validate:"omitempty,min=1,max=100"`
+	Hooks    *HttpHooks        `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+	Retry    *Retry            `yaml:"retry,omitempty" json:"retry,omitempty" validate:"omitempty"`
+	Poll     *Poll             `yaml:"poll,omitempty" json:"poll,omitempty" validate:"omitempty,excluded_with=Retry"`
+	Cookies  *CaseCookies      `yaml:"cookies,omitempty" json:"cookies,omitempty" validate:"omitempty"`
+}
+
+// CaseCookies manages cookie jar of the manifest before the case request is sent,
+// inspect records cookies held by the jar for the case URL after the response
+type CaseCookies struct {
+	Clear   bool              `yaml:"clear,omitempty" json:"clear,omitempty" validate:"omitempty,boolean"`
+	Set     map[string]string `yaml:"set,omitempty" json:"set,omitempty" validate:"omitempty,min=1,max=50"`
+	Inspect bool              `yaml:"inspect,omitempty" json:"inspect,omitempty" validate:"omitempty,boolean"`
+}
+
+// Retry describes when and how often a case request is repeated,
+// without any of onStatus, onError or untilAssert every failed attempt is retried
+type Retry struct {
+	Attempts    int           `yaml:"attempts" json:"attempts" validate:"required,min=1,max=100"`
+	Backoff     string        `yaml:"backoff,omitempty" json:"backoff,omitempty" validate:"omitempty,oneof=fixed exponential"`
+	Delay       time.Duration `yaml:"delay,omitempty" json:"delay,omitempty" validate:"omitempty,duration"`
+	MaxDelay    time.Duration `yaml:"maxDelay,omitempty" json:"maxDelay,omitempty" validate:"omitempty,duration"`
+	Jitter      float64       `yaml:"jitter,omitempty" json:"jitter,omitempty" validate:"omitempty,min=0,max=1"`
+	OnStatus    []int         `yaml:"onStatus,omitempty" json:"onStatus,omitempty" validate:"omitempty,min=1,max=50,dive,min=100,max=599"`
+	OnError     bool          `yaml:"onError,omitempty" json:"onError,omitempty" validate:"omitempty,boolean"`
+	UntilAssert bool          `yaml:"untilAssert,omitempty" json:"untilAssert,omitempty" validate:"omitempty,boolean"`
+}
+
+// Poll repeats case request every interval until the condition on response body holds,
+// condition is a JSON path optionally compared with a value, e.g. `status == "done"`
+type Poll struct {
+	Until    string        `yaml:"until" json:"until" validate:"required,min=1"`
+	Interval time.Duration `yaml:"interval,omitempty" json:"interval,omitempty" validate:"omitempty,duration"`
+	Timeout  time.Duration `yaml:"timeout" json:"timeout" validate:"required,duration"`
+}
+
+type HttpHooks struct {
+	BeforeRequest []hooks.Action `yaml:"beforeRequest,omitempty" json:"beforeRequest,omitempty" validate:"omitempty,dive"`
+	AfterResponse []hooks.Action `yaml:"afterResponse,omitempty" json:"afterResponse,omitempty" validate:"omitempty,dive"`
+	OnFailure     []hooks.Action `yaml:"onFailure,omitempty" json:"onFailure,omitempty" validate:"omitempty,dive"`
+}
+
+// Actions returns hook actions declared for the event, it is safe to call on nil hooks
+func (h *HttpHooks) Actions(event hooks.HookEvent) []hooks.Action {
+	if h == nil {
+		return nil
+	}
+
+	switch event {
+	case hooks.BeforeRequest:
+		return h.BeforeRequest
+	case hooks.AfterResponse:
+		return h.AfterResponse
+	case hooks.OnFailure:
+		return h.OnFailure
+	default:
+		return nil
+	}
 }
 
 type Assert struct {
-	Target   string `yaml:"target,omitempty" json:"target,omitempty" validate:"required,oneof=status body headers"`
-	Equals   any    `yaml:"equals,omitempty" json:"equals,omitempty" validate:"omitempty"`
-	Contains string `yaml:"contains,omitempty" json:"contains,omitempty" validate:"omitempty,min=1"`
-	Exists   bool   `yaml:"exists,omitempty" json:"exists,omitempty" validate:"omitempty,boolean"`
-	Template string `yaml:"template,omitempty" json:"template,omitempty" validate:"omitempty,min=1,contains_template"`
+	Name        string        `yaml:"name,omitempty" json:"name,omitempty" validate:"omitempty,min=1,max=128"`
+	Soft        bool          `yaml:"soft,omitempty" json:"soft,omitempty" validate:"omitempty,boolean"`
+	Target      string        `yaml:"target,omitempty" json:"target,omitempty" validate:"required,oneof=status body headers schema duration size snapshot"`
+	Equals      any           `yaml:"equals,omitempty" json:"equals,omitempty" validate:"omitempty"`
+	Contains    string        `yaml:"contains,omitempty" json:"contains,omitempty" validate:"omitempty,min=1"`
+	Exists      bool          `yaml:"exists,omitempty" json:"exists,omitempty" validate:"omitempty,boolean"`
+	Template    string        `yaml:"template,omitempty" json:"template,omitempty" validate:"omitempty,min=1,contains_template"`
+	Path        string        `yaml:"path,omitempty" json:"path,omitempty" validate:"omitempty,min=1"`
+	Matcher     string        `yaml:"matcher,omitempty" json:"matcher,omitempty" validate:"omitempty,oneof=eq ne gt gte lt lte in regex length type isNull"`
+	Value       any           `yaml:"value,omitempty" json:"value,omitempty" validate:"omitempty"`
+	Each        bool          `yaml:"each,omitempty" json:"each,omitempty" validate:"omitempty,boolean,excluded_with=Any"`
+	Any         bool          `yaml:"any,omitempty" json:"any,omitempty" validate:"omitempty,boolean"`
+	Schema      any           `yaml:"schema,omitempty" json:"schema,omitempty" validate:"required_if=Target schema"`
+	LessThan    time.Duration `yaml:"lessThan,omitempty" json:"lessThan,omitempty" validate:"required_if=Target duration"`
+	BytesAtMost string        `yaml:"bytesAtMost,omitempty" json:"bytesAtMost,omitempty" validate:"required_if=Target size,omitempty,bytesize"`
+	Ignore      []string      `yaml:"ignore,omitempty" json:"ignore,omitempty" validate:"omitempty,max=50,dive,min=1"`
+	Header      string        `yaml:"header,omitempty" json:"header,omitempty" validate:"omitempty,min=1"`
+	Absent      bool          `yaml:"absent,omitempty" json:"absent,omitempty" validate:"omitempty,boolean,excluded_with=Exists"`
+	IgnoreCase  bool          `yaml:"ignoreCase,omitempty" json:"ignoreCase,omitempty" validate:"omitempty,boolean"`
+	Cookie      *CookieAssert `yaml:"cookie,omitempty" json:"cookie,omitempty" validate:"omitempty,excluded_with=Header"`
+}
+
+// CookieAssert checks attributes of a cookie set by the response, value of the cookie is checked with assert matchers
+type CookieAssert struct {
+	Name     string `yaml:"name" json:"name" validate:"required,min=1"`
+	HttpOnly *bool  `yaml:"httpOnly,omitempty" json:"httpOnly,omitempty" validate:"omitempty"`
+	Secure   *bool  `yaml:"secure,omitempty" json:"secure,omitempty" validate:"omitempty"`
+	SameSite string `yaml:"sameSite,omitempty" json:"sameSite,omitempty" validate:"omitempty,oneof=Strict Lax None"`
+	Path     string `yaml:"path,omitempty" json:"path,omitempty" validate:"omitempty,min=1"`
+	Domain   string `yaml:"domain,omitempty" json:"domain,omitempty" validate:"omitempty,min=1"`
 }
 
 type Save struct {
@@ -21,8 +21,9 @@ type Http struct {
 	kinds.BaseManifest `yaml:",inline" json:",inline" validate:"required"`
 
 	Spec struct {
-		Target string     `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-		Cases  []HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+		Target string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+		Cases  []HttpCase       `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+		Hooks  *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
 	} `yaml:"spec" json:"spec" validate:"required"`
 
 	kinds.Dependencies `yaml:",inline" json:",inline" validate:"omitempty"`
@@ -32,15 +33,16 @@ type Http struct {
 type HttpCase struct {
 	tests.HttpCase `yaml:",inline" json:",inline" validate:"required"`
 
-	Type      string        `yaml:"type,omitempty" json:"type,omitempty" validate:"omitempty,oneof=wave ramp step"`
-	Repeats   int           `yaml:"repeats,omitempty" json:"repeats,omitempty" validate:"omitempty,min=1,max=1000"`
-	Agents    int           `yaml:"agents,omitempty" json:"agents,omitempty" validate:"omitempty,min=1,max=1000"`
-	RPS       int           `yaml:"rps,omitempty" json:"rps,omitempty" validate:"omitempty,min=1,max=100000"`
-	Ramp      *RampConfig   `yaml:"ramp,omitempty" json:"ramp,omitempty" validate:"omitempty"`
-	Wave      *WaveConfig   `yaml:"wave,omitempty" json:"wave,omitempty" validate:"omitempty"`
-	Step      *StepConfig   `yaml:"step,omitempty" json:"step,omitempty" validate:"omitempty"`
-	Duration  time.Duration `yaml:"duration,omitempty" json:"duration,omitempty" validate:"omitempty,duration"`
-	SaveEntry int           `yaml:"saveEntry,omitempty" json:"saveEntry,omitempty" validate:"omitempty,min=1,max=1000"` // Int value or precent of saving
+	Type       string        `yaml:"type,omitempty" json:"type,omitempty" validate:"omitempty,oneof=wave ramp step"`
+	Repeats    int           `yaml:"repeats,omitempty" json:"repeats,omitempty" validate:"omitempty,min=1,max=1000"`
+	Agents     int           `yaml:"agents,omitempty" json:"agents,omitempty" validate:"omitempty,min=1,max=1000"`
+	RPS        int           `yaml:"rps,omitempty" json:"rps,omitempty" validate:"omitempty,min=1,max=100000"`
+	Ramp       *RampConfig   `yaml:"ramp,omitempty" json:"ramp,omitempty" validate:"omitempty"`
+	Wave       *WaveConfig   `yaml:"wave,omitempty" json:"wave,omitempty" validate:"omitempty"`
+	Step       *StepConfig   `yaml:"step,omitempty" json:"step,omitempty" validate:"omitempty"`
+	Duration   time.Duration `yaml:"duration,omitempty" json:"duration,omitempty" validate:"omitempty,duration"`
+	SaveEntry  int           `yaml:"saveEntry,omitempty" json:"saveEntry,omitempty" validate:"omitempty,min=1,max=1000"`                // Int value or precent of saving
+	Thresholds []string      `yaml:"thresholds,omitempty" json:"thresholds,omitempty" validate:"omitempty,min=1,max=25,dive,threshold"` // e.g. p95 < 300ms, errorRate < 1%, rps >= 500
 }
 
 type WaveConfig struct {
@@ -0,0 +1,12 @@
+package kinds
+
+// TLS configures transport security of requests to the target, file paths are relative to the manifest file,
+// ca is a PEM bundle trusted in addition to system roots, cert and key are the client certificate for mTLS
+type TLS struct {
+	CA                 string `yaml:"ca,omitempty" json:"ca,omitempty" validate:"omitempty,min=1"`
+	Cert               string `yaml:"cert,omitempty" json:"cert,omitempty" validate:"required_with=Key"`
+	Key                string `yaml:"key,omitempty" json:"key,omitempty" validate:"required_with=Cert"`
+	ServerName         string `yaml:"serverName,omitempty" json:"serverName,omitempty" validate:"omitempty,hostname"`
+	MinVersion         string `yaml:"minVersion,omitempty" json:"minVersion,omitempty" validate:"omitempty,oneof=1.0 1.1 1.2 1.3"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify,omitempty" json:"insecureSkipVerify,omitempty" validate:"omitempty,boolean"`
+}
@@ -0,0 +1,17 @@
+package kinds
+
+import "time"
+
+// Transport configures how requests reach the target, unset values keep Go defaults:
+// proxy from environment, up to 10 followed redirects, HTTP/2 and keep-alive enabled
+type Transport struct {
+	Proxy               string        `yaml:"proxy,omitempty" json:"proxy,omitempty" validate:"omitempty,min=1"`
+	FollowRedirects     *bool         `yaml:"followRedirects,omitempty" json:"followRedirects,omitempty" validate:"omitempty"`
+	MaxRedirects        int           `yaml:"maxRedirects,omitempty" json:"maxRedirects,omitempty" validate:"omitempty,min=1,max=100"`
+	HTTP2               *bool         `yaml:"http2,omitempty" json:"http2,omitempty" validate:"omitempty"`
+	KeepAlive           *bool         `yaml:"keepAlive,omitempty" json:"keepAlive,omitempty" validate:"omitempty"`
+	MaxIdleConns        int           `yaml:"maxIdleConns,omitempty" json:"maxIdleConns,omitempty" validate:"omitempty,min=1"`
+	MaxIdleConnsPerHost int           `yaml:"maxIdleConnsPerHost,omitempty" json:"maxIdleConnsPerHost,omitempty" validate:"omitempty,min=1"`
+	MaxConnsPerHost     int           `yaml:"maxConnsPerHost,omitempty" json:"maxConnsPerHost,omitempty" validate:"omitempty,min=1"`
+	IdleConnTimeout     time.Duration `yaml:"idleConnTimeout,omitempty" json:"idleConnTimeout,omitempty" validate:"omitempty,duration"`
+}
@@ -0,0 +1,194 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+)
+
+var sameSiteModes = map[http.SameSite]string{
+	http.SameSiteStrictMode: "Strict",
+	http.SameSiteLaxMode:    "Lax",
+	http.SameSiteNoneMode:   "None",
+}
+
+// assertHeader checks a named header, without each or any matchers are applied to all header values joined by comma,
+// with each or any they are applied to every value, e.g. to every Set-Cookie
+func (r *Runner) assertHeader(a *tests.Assert, resp *http.Response) error {
+	name := http.CanonicalHeaderKey(a.Header)
+	values := resp.Header.Values(name)
+
+	if a.Absent {
+		if len(values) > 0 {
+			return fmt.Errorf("header %s: expected to be absent, got %q", name, strings.Join(values, ", "))
+		}
+		return nil
+	}
+
+	if len(values) == 0 {
+		return fmt.Errorf("header %s: expected to exist", name)
+	}
+
+	if !hasHeaderMatcher(a) {
+		return nil
+	}
+
+	if !a.Each && !a.Any {
+		if err := matchHeaderValue(a, strings.Join(values, ", ")); err != nil {
+			return fmt.Errorf("header %s: %w", name, err)
+		}
+		return nil
+	}
+
+	var errs []error
+	for i, value := range values {
+		err := matchHeaderValue(a, value)
+		if err == nil && a.Any {
+			return nil
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("header %s value %d: %w", name, i, err))
+			if a.Each {
+				return errs[0]
+			}
+		}
+	}
+
+	if a.Any {
+		return fmt.Errorf("header %s: none of %d values matched: %w", name, len(values), errors.Join(errs...))
+	}
+	return nil
+}
+
+// assertCookie finds a cookie set by the response and checks its attributes and value
+func (r *Runner) assertCookie(a *tests.Assert, resp *http.Response) error {
+	expected := a.Cookie
+
+	var cookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == expected.Name {
+			cookie = c
+			break
+		}
+	}
+
+	if a.Absent {
+		if cookie != nil {
+			return fmt.Errorf("cookie %s: expected to be absent, got %q", expected.Name, cookie.Raw)
+		}
+		return nil
+	}
+
+	if cookie == nil {
+		return fmt.Errorf("cookie %s: expected to be set", expected.Name)
+	}
+
+	var errs []error
+	if expected.HttpOnly != nil && cookie.HttpOnly != *expected.HttpOnly {
+		errs = append(errs, fmt.Errorf("cookie %s: expected HttpOnly %t, got %t", expected.Name, *expected.HttpOnly, cookie.HttpOnly))
+	}
+	if expected.Secure != nil && cookie.Secure != *expected.Secure {
+		errs = append(errs, fmt.Errorf("cookie %s: expected Secure %t, got %t", expected.Name, *expected.Secure, cookie.Secure))
+	}
+	if expected.SameSite != "" && !strings.EqualFold(sameSiteModes[cookie.SameSite], expected.SameSite) {
+		errs = append(errs, fmt.Errorf("cookie %s: expected SameSite %s, got %q", expected.Name, expected.SameSite, sameSiteModes[cookie.SameSite]))
+	}
+	if expected.Path != "" && cookie.Path != expected.Path {
+		errs = append(errs, fmt.Errorf("cookie %s: expected Path %s, got %q", expected.Name, expected.Path, cookie.Path))
+	}
+	if expected.Domain != "" && !strings.EqualFold(strings.TrimPrefix(cookie.Domain, "."), strings.TrimPrefix(expected.Domain, ".")) {
+		errs = append(errs, fmt.Errorf("cookie %s: expected Domain %s, got %q", expected.Name, expected.Domain, cookie.Domain))
+	}
+
+	if hasHeaderMatcher(a) {
+		if err := matchHeaderValue(a, cookie.Value); err != nil {
+			errs = append(errs, fmt.Errorf("cookie %s: %w", expected.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func hasHeaderMatcher(a *tests.Assert) bool {
+	return a.Matcher != "" || a.Equals != nil || a.Contains != ""
+}
+
+// matchHeaderValue applies assert matcher to a header value, values are compared as strings
+// and numeric matchers parse the value as a number
+func matchHeaderValue(a *tests.Assert, value string) error {
+	matcher, expected := a.Matcher, a.Value
+	if matcher == "" {
+		switch {
+		case a.Contains != "":
+			if !strings.Contains(fold(a, value), fold(a, a.Contains)) {
+				return fmt.Errorf("expected %q to contain %q", value, a.Contains)
+			}
+			return nil
+		default:
+			matcher, expected = MatcherEq, a.Equals
+		}
+	}
+
+	switch matcher {
+	case MatcherEq:
+		if fold(a, value) != fold(a, fmt.Sprint(expected)) {
+			return fmt.Errorf("expected %q, got %q", fmt.Sprint(expected), value)
+		}
+	case MatcherNe:
+		if fold(a, value) == fold(a, fmt.Sprint(expected)) {
+			return fmt.Errorf("expected value not equal to %q", fmt.Sprint(expected))
+		}
+	case MatcherGt, MatcherGte, MatcherLt, MatcherLte:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("matcher %s requires number, got %q", matcher, value)
+		}
+		return compareNumbers(matcher, gjson.Parse(value), expected)
+	case MatcherIn:
+		list, ok := expected.([]any)
+		if !ok {
+			return fmt.Errorf("matcher in requires list value, got %T", expected)
+		}
+		if !slices.ContainsFunc(list, func(item any) bool { return fold(a, value) == fold(a, fmt.Sprint(item)) }) {
+			return fmt.Errorf("expected one of %v, got %q", list, value)
+		}
+	case MatcherRegex:
+		pattern := fmt.Sprint(expected)
+		if a.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid regex %v: %w", expected, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("expected %q to match %s", value, re.String())
+		}
+	case MatcherLength:
+		want, err := strconv.Atoi(fmt.Sprint(expected))
+		if err != nil {
+			return fmt.Errorf("matcher length requires integer value, got %v", expected)
+		}
+		if got := len(value); got != want {
+			return fmt.Errorf("expected length %d, got %d", want, got)
+		}
+	default:
+		return fmt.Errorf("matcher %s is not applicable to headers", matcher)
+	}
+
+	return nil
+}
+
+func fold(a *tests.Assert, value string) string {
+	if a.IgnoreCase {
+		return strings.ToLower(value)
+	}
+	return value
+}
@@ -0,0 +1,264 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+	"github.com/tidwall/gjson"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+)
+
+const (
+	MatcherEq     = "eq"
+	MatcherNe     = "ne"
+	MatcherGt     = "gt"
+	MatcherGte    = "gte"
+	MatcherLt     = "lt"
+	MatcherLte    = "lte"
+	MatcherIn     = "in"
+	MatcherRegex  = "regex"
+	MatcherLength = "length"
+	MatcherType   = "type"
+	MatcherIsNull = "isNull"
+)
+
+const bodyRootPath = "@this"
+
+// assertBodyPath checks value found by gjson path with the assert matcher,
+// with each or any the matcher is applied to array elements and failing element path is reported
+func (r *Runner) assertBodyPath(a *tests.Assert, body []byte) error {
+	path := a.Path
+	if path == "" {
+		path = bodyRootPath
+	}
+
+	if !gjson.ValidBytes(body) {
+		return fmt.Errorf("body path %s: response body is not a valid JSON", path)
+	}
+
+	result := gjson.GetBytes(body, path)
+
+	if !a.Each && !a.Any {
+		if err := r.matchValue(a, result); err != nil {
+			return fmt.Errorf("body path %s: %w", path, err)
+		}
+		return nil
+	}
+
+	if !result.IsArray() {
+		return fmt.Errorf("body path %s: expected array for each/any matching, got %s", path, jsonType(result))
+	}
+
+	elements := result.Array()
+	var errs []error
+	for i, elem := range elements {
+		err := r.matchValue(a, elem)
+		if err == nil && a.Any {
+			return nil
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("body path %s: %w", elementPath(path, i), err))
+			if a.Each {
+				return errs[0]
+			}
+		}
+	}
+
+	if a.Any {
+		return fmt.Errorf("body path %s: none of %d elements matched: %w", path, len(elements), errors.Join(errs...))
+	}
+	return nil
+}
+
+func (r *Runner) matchValue(a *tests.Assert, actual gjson.Result) error {
+	matcher, expected := a.Matcher, a.Value
+	if matcher == "" {
+		switch {
+		case a.Exists:
+			if !actual.Exists() {
+				return fmt.Errorf("expected value to exist")
+			}
+			return nil
+		case a.Contains != "":
+			if !strings.Contains(actual.String(), a.Contains) {
+				return fmt.Errorf("expected %s to contain %q", actual.Raw, a.Contains)
+			}
+			return nil
+		case a.Equals != nil:
+			matcher, expected = MatcherEq, a.Equals
+		default:
+			matcher, expected = MatcherEq, a.Value
+		}
+	}
+
+	if matcher == MatcherIsNull {
+		wantNull := true
+		if expected != nil {
+			wantNull = fmt.Sprint(expected) == "true"
+		}
+		if isNull := !actual.Exists() || actual.Type == gjson.Null; isNull != wantNull {
+			return fmt.Errorf("expected null to be %t, got %s", wantNull, rawOrMissing(actual))
+		}
+		return nil
+	}
+
+	if !actual.Exists() {
+		return fmt.Errorf("value does not exist")
+	}
+
+	switch matcher {
+	case MatcherEq:
+		if !jsonEqual(actual.Value(), expected) {
+			return fmt.Errorf("expected %v, got %s", expected, actual.Raw)
+		}
+	case MatcherNe:
+		if jsonEqual(actual.Value(), expected) {
+			return fmt.Errorf("expected value not equal to %v", expected)
+		}
+	case MatcherGt, MatcherGte, MatcherLt, MatcherLte:
+		return compareNumbers(matcher, actual, expected)
+	case MatcherIn:
+		list, ok := expected.([]any)
+		if !ok {
+			return fmt.Errorf("matcher in requires list value, got %T", expected)
+		}
+		if !slices.ContainsFunc(list, func(item any) bool { return jsonEqual(actual.Value(), item) }) {
+			return fmt.Errorf("expected one of %v, got %s", list, actual.Raw)
+		}
+	case MatcherRegex:
+		re, err := regexp.Compile(fmt.Sprint(expected))
+		if err != nil {
+			return fmt.Errorf("invalid regex %v: %w", expected, err)
+		}
+		if !re.MatchString(actual.String()) {
+			return fmt.Errorf("expected %s to match %s", actual.Raw, re.String())
+		}
+	case MatcherLength:
+		want, err := strconv.Atoi(fmt.Sprint(expected))
+		if err != nil {
+			return fmt.Errorf("matcher length requires integer value, got %v", expected)
+		}
+		if got, ok := jsonLength(actual); !ok {
+			return fmt.Errorf("matcher length is not applicable to %s", jsonType(actual))
+		} else if got != want {
+			return fmt.Errorf("expected length %d, got %d", want, got)
+		}
+	case MatcherType:
+		if got := jsonType(actual); got != fmt.Sprint(expected) {
+			return fmt.Errorf("expected type %v, got %s", expected, got)
+		}
+	default:
+		return fmt.Errorf("unknown matcher %s", matcher)
+	}
+
+	return nil
+}
+
+func compareNumbers(matcher string, actual gjson.Result, expected any) error {
+	if actual.Type != gjson.Number {
+		return fmt.Errorf("matcher %s requires number, got %s", matcher, jsonType(actual))
+	}
+
+	want, err := strconv.ParseFloat(fmt.Sprint(expected), 64)
+	if err != nil {
+		return fmt.Errorf("matcher %s requires numeric value, got %v", matcher, expected)
+	}
+
+	got := actual.Float()
+	var ok bool
+	var relation string
+	switch matcher {
+	case MatcherGt:
+		ok, relation = got > want, "greater than"
+	case MatcherGte:
+		ok, relation = got >= want, "greater than or equal to"
+	case MatcherLt:
+		ok, relation = got < want, "less than"
+	case MatcherLte:
+		ok, relation = got <= want, "less than or equal to"
+	}
+
+	if !ok {
+		return fmt.Errorf("expected value %s %v, got %s", relation, expected, actual.Raw)
+	}
+	return nil
+}
+
+// jsonEqual compares values after JSON round trip, so numbers of different Go types are equal
+func jsonEqual(actual, expected any) bool {
+	return reflect.DeepEqual(normalizeJSON(actual), normalizeJSON(expected))
+}
+
+func normalizeJSON(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	var normalized any
+	if err = json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
+
+func jsonType(result gjson.Result) string {
+	switch {
+	case !result.Exists():
+		return "missing"
+	case result.IsArray():
+		return "array"
+	case result.IsObject():
+		return "object"
+	}
+
+	switch result.Type {
+	case gjson.String:
+		return "string"
+	case gjson.Number:
+		return "number"
+	case gjson.True, gjson.False:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+func jsonLength(result gjson.Result) (int, bool) {
+	switch {
+	case result.IsArray():
+		return len(result.Array()), true
+	case result.IsObject():
+		return len(result.Map()), true
+	case result.Type == gjson.String:
+		return utf8.RuneCountInString(result.String()), true
+	default:
+		return 0, false
+	}
+}
+
+func rawOrMissing(result gjson.Result) string {
+	if !result.Exists() {
+		return "missing value"
+	}
+	return result.Raw
+}
+
+// elementPath returns path of the array element, replacing the first # of the path when present
+func elementPath(path string, index int) string {
+	if strings.Contains(path, "#") {
+		return strings.Replace(path, "#", strconv.Itoa(index), 1)
+	}
+	if path == bodyRootPath {
+		return strconv.Itoa(index)
+	}
+	return fmt.Sprintf("%s.%d", path, index)
+}
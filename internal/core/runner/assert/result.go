@@ -0,0 +1,211 @@
+package assert
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+// maxActualLength limits how much of the response is kept as an actual value of the assertion
+const maxActualLength = 256
+
+// describe builds human-readable expected and actual values of the assertion for reporting
+func describe(a *tests.Assert, resp *http.Response, body []byte, duration time.Duration) *interfaces.AssertResult {
+	result := &interfaces.AssertResult{
+		Name:   a.Name,
+		Target: a.Target,
+		Soft:   a.Soft,
+	}
+
+	if result.Name == "" {
+		result.Name = a.Target
+		switch {
+		case a.Path != "":
+			result.Name = fmt.Sprintf("%s %s", a.Target, a.Path)
+		case a.Header != "":
+			result.Name = fmt.Sprintf("%s %s", a.Target, http.CanonicalHeaderKey(a.Header))
+		case a.Cookie != nil:
+			result.Name = fmt.Sprintf("%s cookie %s", a.Target, a.Cookie.Name)
+		}
+	}
+
+	switch a.Target {
+	case Status.String():
+		result.Expected = expectation(a)
+		if resp != nil {
+			result.Actual = resp.Status
+		}
+	case Body.String():
+		if a.Path != "" || a.Matcher != "" || a.Each || a.Any {
+			result.Expected = pathExpectation(a)
+			path := a.Path
+			if path == "" {
+				path = bodyRootPath
+			}
+			result.Actual = truncate(rawOrMissing(gjson.GetBytes(body, path)))
+		} else {
+			result.Expected = expectation(a)
+			result.Actual = truncate(string(body))
+		}
+	case Headers.String():
+		result.Expected = expectation(a)
+		if a.Header != "" || a.Cookie != nil {
+			result.Expected = headerExpectation(a)
+		}
+		if resp != nil {
+			result.Actual = truncate(formatHeaders(a, resp))
+		}
+	case Schema.String():
+		if path, ok := a.Schema.(string); ok {
+			result.Expected = fmt.Sprintf("matches schema %s", path)
+		} else {
+			result.Expected = "matches inline schema"
+		}
+		result.Actual = truncate(string(body))
+	case Duration.String():
+		result.Expected = fmt.Sprintf("< %s", a.LessThan)
+		result.Actual = duration.Round(time.Microsecond).String()
+	case Size.String():
+		result.Expected = fmt.Sprintf("<= %s", a.BytesAtMost)
+		result.Actual = fmt.Sprintf("%d bytes", len(body))
+	case Snapshot.String():
+		result.Expected = "matches snapshot"
+		if len(a.Ignore) > 0 {
+			result.Expected = fmt.Sprintf("matches snapshot ignoring %s", strings.Join(a.Ignore, ", "))
+		}
+		result.Actual = truncate(string(body))
+	}
+
+	return result
+}
+
+func expectation(a *tests.Assert) string {
+	var parts []string
+	if a.Equals != nil {
+		parts = append(parts, fmt.Sprintf("equals %v", a.Equals))
+	}
+	if a.Contains != "" {
+		parts = append(parts, fmt.Sprintf("contains %q", a.Contains))
+	}
+	if a.Exists {
+		parts = append(parts, "exists")
+	}
+	if a.Template != "" {
+		parts = append(parts, fmt.Sprintf("template %s", a.Template))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func pathExpectation(a *tests.Assert) string {
+	var expected string
+	switch {
+	case a.Matcher != "":
+		expected = a.Matcher
+		if a.Value != nil {
+			expected = fmt.Sprintf("%s %v", a.Matcher, a.Value)
+		}
+	case a.Equals != nil:
+		expected = fmt.Sprintf("eq %v", a.Equals)
+	case a.Exists:
+		expected = "exists"
+	}
+
+	switch {
+	case a.Each:
+		expected = fmt.Sprintf("each %s", expected)
+	case a.Any:
+		expected = fmt.Sprintf("any %s", expected)
+	}
+	return expected
+}
+
+func headerExpectation(a *tests.Assert) string {
+	if a.Absent {
+		return "absent"
+	}
+
+	var parts []string
+	if expected := pathExpectation(a); expected != "" {
+		parts = append(parts, expected)
+	}
+	if a.Contains != "" {
+		parts = append(parts, fmt.Sprintf("contains %q", a.Contains))
+	}
+	if c := a.Cookie; c != nil {
+		if c.HttpOnly != nil {
+			parts = append(parts, fmt.Sprintf("HttpOnly=%t", *c.HttpOnly))
+		}
+		if c.Secure != nil {
+			parts = append(parts, fmt.Sprintf("Secure=%t", *c.Secure))
+		}
+		if c.SameSite != "" {
+			parts = append(parts, fmt.Sprintf("SameSite=%s", c.SameSite))
+		}
+		if c.Path != "" {
+			parts = append(parts, fmt.Sprintf("Path=%s", c.Path))
+		}
+		if c.Domain != "" {
+			parts = append(parts, fmt.Sprintf("Domain=%s", c.Domain))
+		}
+	}
+	if len(parts) == 0 {
+		return "exists"
+	}
+	if a.IgnoreCase {
+		parts = append(parts, "ignoring case")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatHeaders renders headers compared by the assertion, or all of them when no keys are expected
+func formatHeaders(a *tests.Assert, resp *http.Response) string {
+	header := resp.Header
+	if a.Cookie != nil {
+		for _, c := range resp.Cookies() {
+			if c.Name == a.Cookie.Name {
+				return c.Raw
+			}
+		}
+		return ""
+	}
+	if a.Header != "" {
+		return strings.Join(header.Values(a.Header), ", ")
+	}
+
+	var keys []string
+	if equals, ok := a.Equals.(map[string]any); ok {
+		for key := range equals {
+			keys = append(keys, http.CanonicalHeaderKey(key))
+		}
+	} else {
+		for key := range header {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", key, strings.Join(header.Values(key), ", ")))
+	}
+	return strings.Join(pairs, "; ")
+}
+
+func truncate(value string) string {
+	if len(value) <= maxActualLength {
+		return value
+	}
+	cut := maxActualLength
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut] + "..."
+}
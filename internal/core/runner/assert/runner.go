@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/goccy/go-json"
 
 	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	"github.com/apiqube/cli/internal/core/runner/form"
 	"github.com/apiqube/cli/internal/core/runner/interfaces"
 	"github.com/apiqube/cli/internal/core/runner/templates"
 )
@@ -18,9 +21,13 @@ import (
 type Type string
 
 const (
-	Status  Type = "status"
-	Body    Type = "body"
-	Headers Type = "headers"
+	Status   Type = "status"
+	Body     Type = "body"
+	Headers  Type = "headers"
+	Schema   Type = "schema"
+	Duration Type = "duration"
+	Size     Type = "size"
+	Snapshot Type = "snapshot"
 )
 
 func (t Type) String() string {
@@ -29,30 +36,85 @@ func (t Type) String() string {
 
 type Runner struct {
 	templateEngine *templates.TemplateEngine
+	passer         *form.Runner
+	schemas        *schemaCache
 }
 
 func NewRunner() *Runner {
 	return &Runner{
 		templateEngine: templates.New(),
+		passer:         form.NewRunner(),
+		schemas:        newSchemaCache(),
 	}
 }
 
-// Assert runs all assertions and aggregates errors.
-func (r *Runner) Assert(ctx interfaces.ExecutionContext, asserts []*tests.Assert, resp *http.Response, body []byte) error {
+// Files locates files of the case, relative schema paths are resolved against directory of the source manifest
+// and snapshot is the case snapshot file, see SnapshotPath
+type Files struct {
+	Source   string
+	Snapshot string
+}
+
+// Assert runs all assertions and aggregates errors of the failed ones, soft assertions never fail,
+// duration is the response time measured by executor
+func (r *Runner) Assert(ctx interfaces.ExecutionContext, asserts []*tests.Assert, resp *http.Response, body []byte, duration time.Duration, files Files) error {
+	_, err := r.Evaluate(ctx, asserts, resp, body, duration, files)
+	return err
+}
+
+// Evaluate runs every assertion and records its outcome individually,
+// returned error joins failures of the assertions not marked as soft
+func (r *Runner) Evaluate(ctx interfaces.ExecutionContext, asserts []*tests.Assert, resp *http.Response, body []byte, duration time.Duration, files Files) ([]*interfaces.AssertResult, error) {
 	var err error
+	results := make([]*interfaces.AssertResult, 0, len(asserts))
+
 	for _, a := range asserts {
+		a = r.resolve(ctx, a)
+
+		var assertErr error
 		switch a.Target {
 		case Status.String():
-			err = errors.Join(err, r.assertStatus(ctx, a, resp))
+			assertErr = r.assertStatus(ctx, a, resp)
 		case Body.String():
-			err = errors.Join(err, r.assertBody(ctx, a, resp, body))
+			assertErr = r.assertBody(ctx, a, resp, body)
 		case Headers.String():
-			err = errors.Join(err, r.assertHeaders(ctx, a, resp))
+			assertErr = r.assertHeaders(ctx, a, resp)
+		case Schema.String():
+			assertErr = r.assertSchema(a, body, files.Source)
+		case Duration.String():
+			assertErr = r.assertDuration(a, duration)
+		case Size.String():
+			assertErr = r.assertSize(a, body)
+		case Snapshot.String():
+			assertErr = r.assertSnapshot(ctx, a, body, files.Snapshot)
 		default:
-			return fmt.Errorf("assert failed: unknown assert target %s", a.Target)
+			assertErr = fmt.Errorf("unknown assert target %s", a.Target)
 		}
+
+		result := describe(a, resp, body, duration)
+		result.Passed = assertErr == nil
+		if assertErr != nil {
+			result.Error = assertErr.Error()
+			if !a.Soft {
+				err = errors.Join(err, assertErr)
+			}
+		}
+
+		results = append(results, result)
 	}
-	return err
+
+	return results, err
+}
+
+// resolve returns a copy of the assertion with expectations resolved against saved values,
+// so expectations may refer to earlier cases like {{ create-user.response.body.id }}
+func (r *Runner) resolve(ctx interfaces.ExecutionContext, a *tests.Assert) *tests.Assert {
+	resolved := *a
+	resolved.Equals = r.passer.ApplyValue(ctx, a.Equals)
+	resolved.Value = r.passer.ApplyValue(ctx, a.Value)
+	resolved.Contains = r.passer.Apply(ctx, a.Contains)
+	resolved.Path = r.passer.Apply(ctx, a.Path)
+	return &resolved
 }
 
 func (r *Runner) assertStatus(_ interfaces.ExecutionContext, a *tests.Assert, resp *http.Response) error {
@@ -74,6 +136,9 @@ func (r *Runner) assertStatus(_ interfaces.ExecutionContext, a *tests.Assert, re
 }
 
 func (r *Runner) assertBody(_ interfaces.ExecutionContext, a *tests.Assert, _ *http.Response, body []byte) error {
+	if a.Path != "" || a.Matcher != "" || a.Each || a.Any {
+		return r.assertBodyPath(a, body)
+	}
 	if a.Exists {
 		if len(body) == 0 {
 			return fmt.Errorf("expected not null body")
@@ -122,6 +187,12 @@ func (r *Runner) assertBody(_ interfaces.ExecutionContext, a *tests.Assert, _ *h
 }
 
 func (r *Runner) assertHeaders(_ interfaces.ExecutionContext, a *tests.Assert, resp *http.Response) error {
+	if a.Cookie != nil {
+		return r.assertCookie(a, resp)
+	}
+	if a.Header != "" {
+		return r.assertHeader(a, resp)
+	}
 	if a.Equals != nil {
 		equals, ok := a.Equals.(map[string]any)
 		if !ok {
@@ -159,6 +230,24 @@ func (r *Runner) assertHeaders(_ interfaces.ExecutionContext, a *tests.Assert, r
 	return nil
 }
 
+// Flatten splits joined assertion errors, so each violation can be reported separately
+func Flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, e := range joined.Unwrap() {
+		errs = append(errs, Flatten(e)...)
+	}
+	return errs
+}
+
 // toInt tries to convert interface{} to int for status code assertions.
 func toInt(val any) (int, error) {
 	switch v := val.(type) {
@@ -170,6 +259,13 @@ func toInt(val any) (int, error) {
 		return int(v), nil
 	case int:
 		return v, nil
+	case float64:
+		return int(v), nil
+	case json.Number:
+		i, err := v.Int64()
+		return int(i), err
+	case string:
+		return strconv.Atoi(strings.TrimSpace(v))
 	default:
 		return 0, fmt.Errorf("cannot convert %T to int", val)
 	}
@@ -0,0 +1,324 @@
+package assert
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	runctx "github.com/apiqube/cli/internal/core/runner/context"
+)
+
+func TestRunnerBodyPathMatchers(t *testing.T) {
+	body := []byte(`{
+		"data": {
+			"total": 3,
+			"name": "users",
+			"deleted": null,
+			"items": [
+				{"id": 1, "role": "admin", "email": "a@example.com"},
+				{"id": 2, "role": "user", "email": "b@example.com"},
+				{"id": -3, "role": "user", "email": "invalid"}
+			]
+		}
+	}`)
+
+	runner := NewRunner()
+	ctx := runctx.NewCtxBuilder().Build()
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+	cases := []struct {
+		name   string
+		assert tests.Assert
+		failAt string
+	}{
+		{name: "eq", assert: tests.Assert{Path: "data.total", Matcher: MatcherEq, Value: 3}},
+		{name: "equals shortcut", assert: tests.Assert{Path: "data.name", Equals: "users"}},
+		{name: "eq fails", assert: tests.Assert{Path: "data.total", Matcher: MatcherEq, Value: 4}, failAt: "data.total"},
+		{name: "ne", assert: tests.Assert{Path: "data.name", Matcher: MatcherNe, Value: "orders"}},
+		{name: "gt", assert: tests.Assert{Path: "data.total", Matcher: MatcherGt, Value: 2}},
+		{name: "lte fails", assert: tests.Assert{Path: "data.total", Matcher: MatcherLte, Value: 2}, failAt: "data.total"},
+		{name: "in", assert: tests.Assert{Path: "data.items.0.role", Matcher: MatcherIn, Value: []any{"admin", "owner"}}},
+		{name: "regex", assert: tests.Assert{Path: "data.items.1.email", Matcher: MatcherRegex, Value: `^\S+@\S+$`}},
+		{name: "length", assert: tests.Assert{Path: "data.items", Matcher: MatcherLength, Value: 3}},
+		{name: "type", assert: tests.Assert{Path: "data.items", Matcher: MatcherType, Value: "array"}},
+		{name: "isNull", assert: tests.Assert{Path: "data.deleted", Matcher: MatcherIsNull}},
+		{name: "isNull false", assert: tests.Assert{Path: "data.name", Matcher: MatcherIsNull, Value: false}},
+		{name: "exists", assert: tests.Assert{Path: "data.missing", Exists: true}, failAt: "data.missing"},
+		{name: "each", assert: tests.Assert{Path: "data.items.#.role", Matcher: MatcherIn, Value: []any{"admin", "user"}, Each: true}},
+		{name: "each fails at element", assert: tests.Assert{Path: "data.items.#.id", Matcher: MatcherGt, Value: 0, Each: true}, failAt: "data.items.2.id"},
+		{name: "any", assert: tests.Assert{Path: "data.items.#.role", Matcher: MatcherEq, Value: "admin", Any: true}},
+		{name: "any fails", assert: tests.Assert{Path: "data.items.#.role", Matcher: MatcherEq, Value: "owner", Any: true}, failAt: "none of 3 elements matched"},
+		{name: "each on non array", assert: tests.Assert{Path: "data.name", Matcher: MatcherEq, Value: "users", Each: true}, failAt: "expected array"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.assert.Target = Body.String()
+			err := runner.Assert(ctx, []*tests.Assert{&tc.assert}, resp, body, 0, Files{})
+			if tc.failAt == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.failAt)
+		})
+	}
+}
+
+func TestRunnerSchema(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"id", "email"},
+		"properties": map[string]any{
+			"id":    map[string]any{"type": "integer", "minimum": 1},
+			"email": map[string]any{"type": "string"},
+			"tags":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+	}
+
+	schemaFile := filepath.Join(t.TempDir(), "user.schema.json")
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(schemaFile, data, 0o600))
+
+	runner := NewRunner()
+	ctx := runctx.NewCtxBuilder().Build()
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+	for name, source := range map[string]any{"inline": schema, "file": schemaFile} {
+		t.Run(name, func(t *testing.T) {
+			asserts := []*tests.Assert{{Target: Schema.String(), Schema: source}}
+
+			require.NoError(t, runner.Assert(ctx, asserts, resp, []byte(`{"id":1,"email":"a@example.com","tags":["x"]}`), 0, Files{}))
+
+			err := runner.Assert(ctx, asserts, resp, []byte(`{"id":0,"tags":["x",2]}`), 0, Files{})
+			require.Error(t, err)
+
+			var messages []string
+			for _, e := range Flatten(err) {
+				messages = append(messages, e.Error())
+			}
+			require.Len(t, messages, 3)
+			require.Contains(t, strings.Join(messages, "\n"), "at /id")
+			require.Contains(t, strings.Join(messages, "\n"), "at /tags/1")
+			require.Contains(t, strings.Join(messages, "\n"), "at /:")
+		})
+	}
+
+	// Inline schema is compiled once and shared by equal schemas regardless of key order
+	first, err := runner.compileSchema(schema, "")
+	require.NoError(t, err)
+	reordered := map[string]any{"properties": schema["properties"], "required": schema["required"], "type": "object"}
+	second, err := runner.compileSchema(reordered, "")
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	require.Len(t, runner.schemas.inlines, 1)
+
+	err = runner.Assert(ctx, []*tests.Assert{{Target: Schema.String(), Schema: "missing.json"}}, resp, []byte(`{}`), 0, Files{})
+	require.ErrorContains(t, err, "schema compile failed")
+
+	// Relative schema path is resolved against the manifest file, not the working directory
+	relative := []*tests.Assert{{Target: Schema.String(), Schema: filepath.Base(schemaFile)}}
+	files := Files{Source: filepath.Join(filepath.Dir(schemaFile), "http.yaml")}
+	require.NoError(t, runner.Assert(ctx, relative, resp, []byte(`{"id":1,"email":"a@example.com","tags":["x"]}`), 0, files))
+	require.ErrorContains(t, runner.Assert(ctx, relative, resp, []byte(`{}`), 0, Files{}), "schema compile failed")
+}
+
+func TestRunnerDurationAndSize(t *testing.T) {
+	runner := NewRunner()
+	ctx := runctx.NewCtxBuilder().Build()
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	body := []byte(strings.Repeat("x", 2048))
+
+	fast := []*tests.Assert{{Target: Duration.String(), LessThan: time.Millisecond * 200}}
+	require.NoError(t, runner.Assert(ctx, fast, resp, body, time.Millisecond*50, Files{}))
+	require.ErrorContains(t, runner.Assert(ctx, fast, resp, body, time.Millisecond*250, Files{}), "less than 200ms")
+
+	small := []*tests.Assert{{Target: Size.String(), BytesAtMost: "2KB"}}
+	require.NoError(t, runner.Assert(ctx, small, resp, body, 0, Files{}))
+	require.ErrorContains(t, runner.Assert(ctx, small, resp, append(body, 'x'), 0, Files{}), "got 2049 bytes")
+
+	for raw, expected := range map[string]int64{"512": 512, "100B": 100, "64kb": 64 << 10, "1.5MB": 3 << 19, "1GB": 1 << 30} {
+		size, err := ParseSize(raw)
+		require.NoError(t, err, raw)
+		require.Equal(t, expected, size, raw)
+	}
+
+	_, err := ParseSize("many")
+	require.Error(t, err)
+}
+
+func TestRunnerResolvesSavedValues(t *testing.T) {
+	runner := NewRunner()
+	ctx := runctx.NewCtxBuilder().Build()
+	ctx.Set("create-user", map[string]any{
+		"response": map[string]any{
+			"status": 201,
+			"body":   map[string]any{"id": json.Number("42"), "name": "alice"},
+		},
+	})
+
+	resp := &http.Response{StatusCode: http.StatusCreated, Header: http.Header{"X-User": []string{"alice"}}}
+	body := []byte(`{"user":{"id":42,"name":"alice"}}`)
+
+	require.NoError(t, runner.Assert(ctx, []*tests.Assert{
+		{Target: Status.String(), Equals: "{{ create-user.response.status }}"},
+		{Target: Body.String(), Path: "user.id", Equals: "{{ create-user.response.body.id }}"},
+		{Target: Body.String(), Path: "user.name", Matcher: MatcherIn, Value: []any{"{{ create-user.response.body.name }}", "bob"}},
+		{Target: Body.String(), Contains: `"name":"{{ create-user.response.body.name }}"`},
+		{Target: Headers.String(), Equals: map[string]any{"X-User": "{{ create-user.response.body.name }}"}},
+	}, resp, body, 0, Files{}))
+
+	ctx.Set("create-user", map[string]any{"response": map[string]any{"body": map[string]any{"id": 7}}})
+	require.Error(t, runner.Assert(ctx, []*tests.Assert{
+		{Target: Body.String(), Path: "user.id", Equals: "{{ create-user.response.body.id }}"},
+	}, resp, body, 0, Files{}))
+}
+
+func TestRunnerEvaluate(t *testing.T) {
+	runner := NewRunner()
+	ctx := runctx.NewCtxBuilder().Build()
+	resp := &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{"Content-Type": []string{"application/json"}}}
+	body := []byte(`{"user":{"id":3,"name":"alice"}}`)
+
+	results, err := runner.Evaluate(ctx, []*tests.Assert{
+		{Target: Status.String(), Equals: 200},
+		{Name: "user name", Target: Body.String(), Path: "user.name", Matcher: MatcherEq, Value: "bob", Soft: true},
+		{Target: Duration.String(), LessThan: time.Second},
+	}, resp, body, 20*time.Millisecond, Files{})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.Equal(t, "status", results[0].Name)
+	require.True(t, results[0].Passed)
+	require.Equal(t, "equals 200", results[0].Expected)
+	require.Equal(t, "200 OK", results[0].Actual)
+
+	require.Equal(t, "user name", results[1].Name)
+	require.False(t, results[1].Passed)
+	require.True(t, results[1].Soft)
+	require.Equal(t, "eq bob", results[1].Expected)
+	require.Equal(t, `"alice"`, results[1].Actual)
+	require.Contains(t, results[1].Error, "body path user.name")
+
+	require.True(t, results[2].Passed)
+	require.Equal(t, "20ms", results[2].Actual)
+
+	results, err = runner.Evaluate(ctx, []*tests.Assert{
+		{Target: Body.String(), Path: "user.id", Matcher: MatcherGt, Value: 5},
+		{Target: Size.String(), BytesAtMost: "1KB"},
+	}, resp, body, 0, Files{})
+	require.Error(t, err)
+	require.Len(t, Flatten(err), 1)
+	require.Equal(t, "body user.id", results[0].Name)
+	require.False(t, results[0].Passed)
+	require.Equal(t, "3", results[0].Actual)
+	require.True(t, results[1].Passed)
+	require.Equal(t, "32 bytes", results[1].Actual)
+}
+
+func TestRunnerSnapshot(t *testing.T) {
+	runner := NewRunner()
+	ctx := runctx.NewCtxBuilder().Build()
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+	source := filepath.Join(t.TempDir(), "http_test.yaml")
+	path := SnapshotPath(source, "default", "users-api", "Fetch User #1")
+	require.Equal(t, filepath.Join(filepath.Dir(source), "__snapshots__", "default", "users-api", "fetch-user-1.snap"), path)
+	require.NotEqual(t, path, SnapshotPath(source, "staging", "users-api", "Fetch User #1"))
+
+	asserts := []*tests.Assert{{Target: Snapshot.String(), Ignore: []string{"createdAt", "items.#.id"}}}
+
+	first := []byte(`{"name":"alice","createdAt":"2024-01-01","items":[{"id":1,"sku":"a"},{"id":2,"sku":"b"}]}`)
+	require.NoError(t, runner.Assert(ctx, asserts, resp, first, 0, Files{Snapshot: path}))
+
+	stored, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(stored), `"createdAt": "<ignored>"`)
+	require.Less(t, strings.Index(string(stored), `"createdAt"`), strings.Index(string(stored), `"name"`))
+
+	reordered := []byte(`{"items":[{"sku":"a","id":7},{"sku":"b","id":8}],"createdAt":"2025-06-01","name":"alice"}`)
+	require.NoError(t, runner.Assert(ctx, asserts, resp, reordered, 0, Files{Snapshot: path}))
+
+	changed := []byte(`{"name":"bob","createdAt":"2024-01-01","items":[{"id":1,"sku":"a"},{"id":2,"sku":"b"}]}`)
+	require.ErrorContains(t, runner.Assert(ctx, asserts, resp, changed, 0, Files{Snapshot: path}), `expected "\"name\": \"alice\"", got "\"name\": \"bob\""`)
+
+	updateCtx := runctx.NewCtxBuilder().WithContext(WithUpdateSnapshots(t.Context())).Build()
+	require.NoError(t, runner.Assert(updateCtx, asserts, resp, changed, 0, Files{Snapshot: path}))
+	require.NoError(t, runner.Assert(ctx, asserts, resp, changed, 0, Files{Snapshot: path}))
+
+	named := []*tests.Assert{{Name: "Raw Text", Target: Snapshot.String()}}
+	require.NoError(t, runner.Assert(ctx, named, resp, []byte("plain text"), 0, Files{Snapshot: path}))
+	_, err = os.Stat(filepath.Join(filepath.Dir(path), "fetch-user-1.raw-text.snap"))
+	require.NoError(t, err)
+
+	require.Error(t, runner.Assert(ctx, asserts, resp, first, 0, Files{}))
+}
+
+func TestRunnerHeaderMatchers(t *testing.T) {
+	runner := NewRunner()
+	ctx := runctx.NewCtxBuilder().Build()
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{
+		"Content-Type":   []string{"application/JSON; charset=utf-8"},
+		"Content-Length": []string{"42"},
+		"X-Request-Id":   []string{"req-0f3a"},
+		"Set-Cookie": []string{
+			"session=abc123; Path=/; Domain=example.com; HttpOnly; Secure; SameSite=Strict",
+			"theme=dark; Path=/",
+		},
+	}}
+
+	yes, no := true, false
+
+	cases := []struct {
+		name   string
+		assert tests.Assert
+		failAt string
+	}{
+		{name: "exists", assert: tests.Assert{Header: "x-request-id", Exists: true}},
+		{name: "exists fails", assert: tests.Assert{Header: "X-Trace-Id", Exists: true}, failAt: "X-Trace-Id: expected to exist"},
+		{name: "absent", assert: tests.Assert{Header: "X-Trace-Id", Absent: true}},
+		{name: "absent fails", assert: tests.Assert{Header: "X-Request-Id", Absent: true}, failAt: "expected to be absent"},
+		{name: "equals", assert: tests.Assert{Header: "X-Request-Id", Equals: "req-0f3a"}},
+		{name: "equals case sensitive", assert: tests.Assert{Header: "Content-Type", Equals: "application/json; charset=utf-8"}, failAt: "Content-Type"},
+		{name: "equals ignore case", assert: tests.Assert{Header: "Content-Type", Equals: "application/json; charset=utf-8", IgnoreCase: true}},
+		{name: "contains ignore case", assert: tests.Assert{Header: "Content-Type", Contains: "json", IgnoreCase: true}},
+		{name: "regex", assert: tests.Assert{Header: "X-Request-Id", Matcher: MatcherRegex, Value: `^req-[0-9a-f]{4}$`}},
+		{name: "numeric", assert: tests.Assert{Header: "Content-Length", Matcher: MatcherLte, Value: 100}},
+		{name: "numeric on text", assert: tests.Assert{Header: "X-Request-Id", Matcher: MatcherGt, Value: 1}, failAt: "requires number"},
+		{name: "any set cookie", assert: tests.Assert{Header: "Set-Cookie", Matcher: MatcherRegex, Value: `^theme=`, Any: true}},
+		{name: "each set cookie", assert: tests.Assert{Header: "Set-Cookie", Contains: "HttpOnly", Each: true}, failAt: "Set-Cookie value 1"},
+		{name: "cookie attributes", assert: tests.Assert{Cookie: &tests.CookieAssert{Name: "session", HttpOnly: &yes, Secure: &yes, SameSite: "Strict", Path: "/", Domain: "example.com"}}},
+		{name: "cookie value", assert: tests.Assert{Cookie: &tests.CookieAssert{Name: "session"}, Matcher: MatcherRegex, Value: `^[a-z0-9]+$`}},
+		{name: "cookie attributes fail", assert: tests.Assert{Cookie: &tests.CookieAssert{Name: "theme", HttpOnly: &yes, Secure: &no, SameSite: "Lax"}}, failAt: "cookie theme: expected HttpOnly true"},
+		{name: "cookie missing", assert: tests.Assert{Cookie: &tests.CookieAssert{Name: "token"}}, failAt: "cookie token: expected to be set"},
+		{name: "cookie absent", assert: tests.Assert{Cookie: &tests.CookieAssert{Name: "token"}, Absent: true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.assert.Target = Headers.String()
+			err := runner.Assert(ctx, []*tests.Assert{&tc.assert}, resp, nil, 0, Files{})
+			if tc.failAt == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.failAt)
+		})
+	}
+
+	results, err := runner.Evaluate(ctx, []*tests.Assert{
+		{Target: Headers.String(), Cookie: &tests.CookieAssert{Name: "session", Secure: &yes}},
+	}, resp, nil, 0, Files{})
+	require.NoError(t, err)
+	require.Equal(t, "headers cookie session", results[0].Name)
+	require.Equal(t, "Secure=true", results[0].Expected)
+	require.Contains(t, results[0].Actual, "session=abc123")
+}
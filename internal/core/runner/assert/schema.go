@@ -0,0 +1,142 @@
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+)
+
+const inlineSchemaURL = "inline.json"
+
+// schemaCache keeps compiled schema files by path and inline schemas by their canonical JSON,
+// so every case, poll or retry attempt validating against the same schema compiles it once
+type schemaCache struct {
+	mx      sync.Mutex
+	schemas map[string]*jsonschema.Schema
+	inlines map[string]*jsonschema.Schema
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{
+		schemas: make(map[string]*jsonschema.Schema),
+		inlines: make(map[string]*jsonschema.Schema),
+	}
+}
+
+// assertSchema validates body against inline schema or schema file path relative to the source manifest,
+// every violation is returned as separate error with JSON pointer of the invalid value
+func (r *Runner) assertSchema(a *tests.Assert, body []byte, source string) error {
+	schema, err := r.compileSchema(a.Schema, source)
+	if err != nil {
+		return fmt.Errorf("schema compile failed: %w", err)
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("schema validation failed: response body is not a valid JSON: %w", err)
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return fmt.Errorf("schema validation failed: %w", err)
+	}
+
+	var errs []error
+	for _, unit := range validationErr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		pointer := unit.InstanceLocation
+		if pointer == "" {
+			pointer = "/"
+		}
+		errs = append(errs, fmt.Errorf("schema violation at %s: %s", pointer, unit.Error.String()))
+	}
+
+	if len(errs) == 0 {
+		return fmt.Errorf("schema validation failed: %w", err)
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Runner) compileSchema(raw any, source string) (*jsonschema.Schema, error) {
+	switch v := raw.(type) {
+	case nil:
+		return nil, errors.New("schema is not set")
+	case string:
+		if !filepath.IsAbs(v) && source != "" {
+			v = filepath.Join(filepath.Dir(source), v)
+		}
+		return r.schemas.file(v)
+	default:
+		// Map keys are encoded sorted, so equal schemas share the same canonical JSON
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("inline schema encode failed: %w", err)
+		}
+		return r.schemas.inline(data)
+	}
+}
+
+func (c *schemaCache) inline(data []byte) (*jsonschema.Schema, error) {
+	key := string(data)
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if schema, ok := c.inlines[key]; ok {
+		return schema, nil
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("inline schema decode failed: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err = compiler.AddResource(inlineSchemaURL, doc); err != nil {
+		return nil, err
+	}
+
+	schema, err := compiler.Compile(inlineSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c.inlines[key] = schema
+	return schema, nil
+}
+
+func (c *schemaCache) file(path string) (*jsonschema.Schema, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("schema path %s is invalid: %w", path, err)
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if schema, ok := c.schemas[abs]; ok {
+		return schema, nil
+	}
+
+	schema, err := jsonschema.NewCompiler().Compile(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	c.schemas[abs] = schema
+	return schema, nil
+}
@@ -0,0 +1,206 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/goccy/go-json"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+)
+
+const (
+	snapshotDir       = "__snapshots__"
+	snapshotExt       = ".snap"
+	snapshotIgnored   = "<ignored>"
+	snapshotAllValues = "#"
+)
+
+type contextKey string
+
+const updateSnapshotsKey contextKey = "updateSnapshots"
+
+// WithUpdateSnapshots marks the run context, so snapshot assertions rewrite stored snapshots instead of comparing
+func WithUpdateSnapshots(ctx context.Context) context.Context {
+	return context.WithValue(ctx, updateSnapshotsKey, true)
+}
+
+func updateSnapshots(ctx context.Context) bool {
+	update, _ := ctx.Value(updateSnapshotsKey).(bool)
+	return update
+}
+
+// SnapshotPath returns snapshot file of the case, snapshots live in __snapshots__ directory next to the manifest source,
+// or in the working directory when the source is unknown, grouped by namespace so same named manifests do not collide
+func SnapshotPath(source, namespace, manifestName, caseName string) string {
+	dir := "."
+	if source != "" {
+		dir = filepath.Dir(source)
+	}
+	return filepath.Join(dir, snapshotDir, slug(namespace), slug(manifestName), slug(caseName)+snapshotExt)
+}
+
+// assertSnapshot compares normalized body with the stored snapshot, missing snapshot is written on the first run
+func (r *Runner) assertSnapshot(ctx context.Context, a *tests.Assert, body []byte, path string) error {
+	if path == "" {
+		return fmt.Errorf("snapshot: location of the snapshot is unknown")
+	}
+
+	if a.Name != "" {
+		path = strings.TrimSuffix(path, snapshotExt) + "." + slug(a.Name) + snapshotExt
+	}
+
+	actual, err := normalizeSnapshot(body, a.Ignore)
+	if err != nil {
+		return fmt.Errorf("snapshot %s: %w", path, err)
+	}
+
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && updateSnapshots(ctx)) {
+		if err = writeSnapshot(path, actual); err != nil {
+			return fmt.Errorf("snapshot %s: %w", path, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("snapshot %s: read failed: %w", path, err)
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(expected), bytes.TrimSpace(actual)) {
+		return fmt.Errorf("snapshot %s mismatch: %s, run with --update-snapshots to accept changes", path, firstDiff(expected, actual))
+	}
+	return nil
+}
+
+// normalizeSnapshot renders JSON body with sorted keys and ignored paths replaced,
+// body which is not a valid JSON is kept as is
+func normalizeSnapshot(body []byte, ignore []string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return append(bytes.TrimSpace(body), '\n'), nil
+	}
+
+	for _, path := range ignore {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+		if path == "" {
+			continue
+		}
+		value = ignoreValue(value, strings.Split(path, "."))
+	}
+
+	normalized := &bytes.Buffer{}
+	encoder := json.NewEncoder(normalized)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("normalize body failed: %w", err)
+	}
+	return normalized.Bytes(), nil
+}
+
+// ignoreValue replaces value found by the path segments, # segment matches every element or key
+func ignoreValue(value any, segments []string) any {
+	if len(segments) == 0 {
+		return snapshotIgnored
+	}
+
+	segment, rest := segments[0], segments[1:]
+	switch v := value.(type) {
+	case map[string]any:
+		if segment == snapshotAllValues {
+			for key, elem := range v {
+				v[key] = ignoreValue(elem, rest)
+			}
+		} else if elem, ok := v[segment]; ok {
+			v[segment] = ignoreValue(elem, rest)
+		}
+	case []any:
+		if segment == snapshotAllValues {
+			for i, elem := range v {
+				v[i] = ignoreValue(elem, rest)
+			}
+		} else if index, err := parseIndex(segment, len(v)); err == nil {
+			v[index] = ignoreValue(v[index], rest)
+		}
+	}
+	return value
+}
+
+func parseIndex(segment string, length int) (int, error) {
+	index, err := strconv.Atoi(segment)
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 || index >= length {
+		return 0, fmt.Errorf("index %d out of range", index)
+	}
+	return index, nil
+}
+
+func writeSnapshot(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create snapshot directory failed: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write failed: %w", err)
+	}
+	return nil
+}
+
+// firstDiff describes the first line where snapshot and actual body differ
+func firstDiff(expected, actual []byte) string {
+	expectedLines := strings.Split(strings.TrimSpace(string(expected)), "\n")
+	actualLines := strings.Split(strings.TrimSpace(string(actual)), "\n")
+
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		var want, got string
+		if i < len(expectedLines) {
+			want = strings.TrimSpace(expectedLines[i])
+		}
+		if i < len(actualLines) {
+			got = strings.TrimSpace(actualLines[i])
+		}
+		if want != got {
+			return fmt.Sprintf("line %d expected %q, got %q", i+1, want, got)
+		}
+	}
+	return "bodies differ"
+}
+
+func slug(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+// SplitSnapshots separates snapshot assertions from the others, snapshot assertions write or compare
+// snapshot files, so they have to run once against the final response rather than on every attempt
+func SplitSnapshots(asserts []*tests.Assert) (regular, snapshots []*tests.Assert) {
+	for _, a := range asserts {
+		if a.Target == Snapshot.String() {
+			snapshots = append(snapshots, a)
+		} else {
+			regular = append(regular, a)
+		}
+	}
+	return regular, snapshots
+}
@@ -0,0 +1,63 @@
+package assert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+)
+
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseSize parses size like "512", "100B", "64KB" or "1.5MB", units are binary multiples of 1024
+func ParseSize(raw string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(raw))
+
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, multiplier = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), unit.bytes
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size %q, expected number of bytes with optional B, KB, MB or GB unit", raw)
+	}
+
+	return int64(number * float64(multiplier)), nil
+}
+
+func (r *Runner) assertDuration(a *tests.Assert, duration time.Duration) error {
+	if a.LessThan > 0 && duration >= a.LessThan {
+		return fmt.Errorf("expected response time less than %s, got %s", a.LessThan, duration.Round(time.Microsecond))
+	}
+	return nil
+}
+
+func (r *Runner) assertSize(a *tests.Assert, body []byte) error {
+	if a.BytesAtMost == "" {
+		return nil
+	}
+
+	limit, err := ParseSize(a.BytesAtMost)
+	if err != nil {
+		return err
+	}
+
+	if size := int64(len(body)); size > limit {
+		return fmt.Errorf("expected response size at most %s (%d bytes), got %d bytes", a.BytesAtMost, limit, size)
+	}
+	return nil
+}
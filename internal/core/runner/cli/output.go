@@ -58,6 +58,24 @@ func (o *Output) EndCase(manifest manifests.Manifest, caseName string, result *i
 			errorsFormatted = fmt.Sprintf("\nErrors: %s", errorsBuilder.String())
 		}
 
+		metricsFormatted := ""
+		if len(result.Metrics) > 0 {
+			var metricsBuilder strings.Builder
+			for _, metric := range result.Metrics {
+				metricsBuilder.WriteString(fmt.Sprintf("\n- %s: %s", metric.Name, formatMetric(metric)))
+			}
+			metricsFormatted = fmt.Sprintf("\nMetrics: %s", metricsBuilder.String())
+		}
+
+		assertsFormatted := ""
+		if len(result.Asserts) > 0 {
+			var assertsBuilder strings.Builder
+			for _, a := range result.Asserts {
+				assertsBuilder.WriteString(fmt.Sprintf("\n- %s", formatAssert(a)))
+			}
+			assertsFormatted = fmt.Sprintf("\nAsserts: %s", assertsBuilder.String())
+		}
+
 		detailsFormatted := ""
 		if len(result.Details) > 0 {
 			var detailsBuilder strings.Builder
@@ -98,6 +116,14 @@ func (o *Output) EndCase(manifest manifests.Manifest, caseName string, result *i
 			builder.WriteString(fmt.Sprintf("\nDuration: %s", cli.LogPair{Message: result.Duration.String()}.String()))
 		}
 
+		if len(assertsFormatted) > 0 {
+			builder.WriteString(cli.LogPair{Message: assertsFormatted, Style: &cli.InfoStyle}.String())
+		}
+
+		if len(metricsFormatted) > 0 {
+			builder.WriteString(cli.LogPair{Message: metricsFormatted, Style: &cli.InfoStyle}.String())
+		}
+
 		if len(errorsFormatted) > 0 {
 			builder.WriteString(cli.LogPair{Message: errorsFormatted, Style: &cli.ErrorStyle}.String())
 		}
@@ -168,3 +194,34 @@ func (o *Output) DumpValues(values map[string]any) {
 func (o *Output) Error(err error) {
 	cli.Error(err.Error())
 }
+
+func formatMetric(metric *interfaces.MetricResult) string {
+	formatted := strings.TrimSpace(fmt.Sprintf("%.2f %s", metric.Value, metric.Unit))
+
+	if metric.Threshold != "" {
+		status := "passed"
+		if metric.Failed {
+			status = "failed"
+		}
+		formatted = fmt.Sprintf("%s (threshold %s %s)", formatted, metric.Threshold, status)
+	}
+
+	return formatted
+}
+
+func formatAssert(a *interfaces.AssertResult) string {
+	status := "passed"
+	if !a.Passed {
+		status = "failed"
+		if a.Soft {
+			status = "failed (soft)"
+		}
+	}
+
+	formatted := fmt.Sprintf("%s [%s]: %s, expected %s, actual %s", a.Name, a.Target, status, a.Expected, a.Actual)
+	if a.Error != "" {
+		formatted = fmt.Sprintf("%s (%s)", formatted, a.Error)
+	}
+
+	return formatted
+}
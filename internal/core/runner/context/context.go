@@ -72,7 +72,7 @@ func (c *ctxBaseImpl) GetManifestsByKind(kind string) ([]manifests.Manifest, err
 	}
 
 	if len(ret) == 0 {
-		return nil, fmt.Errorf("no such manifest: %s", kind)
+		return nil, fmt.Errorf("%w: %s", interfaces.ErrManifestNotFound, kind)
 	}
 
 	return ret, nil
@@ -36,8 +36,14 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-					Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+					Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+					CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+					Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					Target: "http://127.0.0.1:8080",
 					Cases: []api.HttpCase{
@@ -108,8 +114,14 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-					Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+					Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+					CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+					Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					Target: "http://127.0.0.1:8080",
 					Cases: []api.HttpCase{
@@ -164,8 +176,14 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-					Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+					Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+					CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+					Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					Target: "http://127.0.0.1:8080",
 					Cases: []api.HttpCase{
@@ -259,9 +277,13 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					BaseURL string            `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
-					Health  string            `yaml:"health" json:"health" validate:"omitempty,max=100"`
-					Headers map[string]string `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+					BaseURL   string             `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
+					Health    string             `yaml:"health" json:"health" validate:"omitempty,max=100"`
+					Headers   map[string]string  `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+					Ready     *servers.Readiness `yaml:"readiness,omitempty" json:"readiness,omitempty" validate:"omitempty"`
+					Auth      *kinds.Auth        `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS         `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport   `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					BaseURL: "http://127.0.0.1:8080",
 					Health:  "",
@@ -280,8 +302,14 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-					Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+					Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+					CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+					Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					Target: "http://127.0.0.1:8080",
 					Cases: []api.HttpCase{
@@ -306,8 +334,14 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-					Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+					Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+					CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+					Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					Target: "http://127.0.0.1:8080",
 					Cases: []api.HttpCase{
@@ -431,9 +465,13 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					BaseURL string            `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
-					Health  string            `yaml:"health" json:"health" validate:"omitempty,max=100"`
-					Headers map[string]string `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+					BaseURL   string             `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
+					Health    string             `yaml:"health" json:"health" validate:"omitempty,max=100"`
+					Headers   map[string]string  `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+					Ready     *servers.Readiness `yaml:"readiness,omitempty" json:"readiness,omitempty" validate:"omitempty"`
+					Auth      *kinds.Auth        `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS         `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport   `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					BaseURL: "http://127.0.0.1:8080",
 					Health:  "",
@@ -452,8 +490,14 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-					Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+					Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+					CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+					Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					Target: "http://127.0.0.1:8080",
 					Cases: []api.HttpCase{
@@ -478,8 +522,14 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-					Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+					Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+					CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+					Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					Target: "http://127.0.0.1:8080",
 					Cases: []api.HttpCase{
@@ -525,8 +575,14 @@ func TestGraphBuilder(t *testing.T) {
 					},
 				},
 				Spec: struct {
-					Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-					Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+					Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+					Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+					CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+					Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+					Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+					TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+					Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 				}{
 					Target: "http://127.0.0.1:8080",
 					Cases: []api.HttpCase{
@@ -10,9 +10,10 @@ import (
 
 var DefaultRegistry = &DefaultExecutorRegistry{
 	executors: map[string]interfaces.Executor{
-		manifests.ValuesKind:   executors.NewValuesExecutor(),
-		manifests.ServerKind:   executors.NewServerExecutor(),
-		manifests.HttpTestKind: executors.NewHTTPExecutor(),
+		manifests.ValuesKind:       executors.NewValuesExecutor(),
+		manifests.ServerKind:       executors.NewServerExecutor(),
+		manifests.HttpTestKind:     executors.NewHTTPExecutor(),
+		manifests.HttpLoadTestKind: executors.NewHTTPLoadExecutor(),
 	},
 }
 
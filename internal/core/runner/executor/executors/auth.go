@@ -0,0 +1,321 @@
+package executors
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds"
+	"github.com/apiqube/cli/internal/core/runner/form"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+const (
+	authAPIKeyQuery = "query"
+
+	oauth2GrantClientCredentials = "client_credentials"
+	oauth2GrantPassword          = "password"
+	oauth2GrantRefreshToken      = "refresh_token"
+
+	oauth2RequestTimeout = time.Second * 10
+	oauth2ExpirySkew     = time.Second * 10
+	oauth2ExpirySkewPart = 4 // Short lived tokens are refreshed after the last quarter of lifetime at most
+	oauth2MaxBodySize    = 1 << 20
+)
+
+// unresolvedTemplateRe matches template left in the value when its value or environment variable is missing
+var unresolvedTemplateRe = regexp.MustCompile(`\{\{\s*[^}]*?\s*}}`)
+
+// applyAuth adds credentials of the auth provider to the request url or headers,
+// headers already set by the case are not overridden, OAuth2 tokens are requested through the target transport,
+// returned token source is set only for OAuth2 auth and renews the header right before every request
+func applyAuth(ctx interfaces.ExecutionContext, passer *form.Runner, tokens *tokenCache, auth *kinds.Auth, transport transportConfig, rawURL string, headers map[string]string) (string, map[string]string, *tokenSource, error) {
+	if auth == nil {
+		return rawURL, headers, nil, nil
+	}
+
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+
+	creds := &credentials{ctx: ctx, passer: passer, scope: "auth"}
+
+	switch {
+	case auth.Basic != nil:
+		username, password := creds.Apply("basic username", auth.Basic.Username), creds.Apply("basic password", auth.Basic.Password)
+		if creds.err != nil {
+			return "", nil, nil, creds.err
+		}
+		setDefaultHeader(headers, "Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	case auth.Bearer != nil:
+		token := creds.Apply("bearer token", auth.Bearer.Token)
+		if creds.err != nil {
+			return "", nil, nil, creds.err
+		}
+		setDefaultHeader(headers, "Authorization", "Bearer "+token)
+	case auth.APIKey != nil:
+		name, value := creds.Apply("api key name", auth.APIKey.Name), creds.Apply("api key value", auth.APIKey.Value)
+		if creds.err != nil {
+			return "", nil, nil, creds.err
+		}
+
+		if auth.APIKey.In != authAPIKeyQuery {
+			setDefaultHeader(headers, name, value)
+			break
+		}
+
+		var err error
+		if rawURL, err = withQuery(rawURL, map[string]any{name: value}); err != nil {
+			return "", nil, nil, err
+		}
+	case auth.OAuth2 != nil:
+		if hasHeader(headers, "Authorization") {
+			break
+		}
+
+		resolved := resolveOAuth2(creds, auth.OAuth2)
+		if creds.err != nil {
+			return "", nil, nil, creds.err
+		}
+
+		source := &tokenSource{tokens: tokens, auth: resolved, transport: transport}
+		token, err := source.Token(ctx)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		headers["Authorization"] = "Bearer " + token
+		return rawURL, headers, source, nil
+	}
+
+	return rawURL, headers, nil, nil
+}
+
+// credentials resolves templated credentials of auth or signing scope, the first credential with unresolved
+// template is kept as error, so the case fails instead of sending the template text to the target
+type credentials struct {
+	ctx    interfaces.ExecutionContext
+	passer *form.Runner
+	scope  string
+	err    error
+}
+
+func (c *credentials) Apply(name, value string) string {
+	resolved := c.passer.Apply(c.ctx, value)
+	if c.err == nil {
+		if template := unresolvedTemplateRe.FindString(resolved); template != "" {
+			c.err = fmt.Errorf("%s %s has unresolved template %s, make sure referenced values and environment variables are set", c.scope, name, template)
+		}
+	}
+	return resolved
+}
+
+func setDefaultHeader(headers map[string]string, name, value string) {
+	if !hasHeader(headers, name) {
+		headers[http.CanonicalHeaderKey(name)] = value
+	}
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenSource is the resolved OAuth2 auth of a request, token is taken from the cache before every request,
+// so token expired during polling, retries or load run is refreshed instead of being sent again
+type tokenSource struct {
+	tokens    *tokenCache
+	auth      kinds.OAuth2Auth
+	transport transportConfig
+}
+
+func (s *tokenSource) Token(ctx context.Context) (string, error) {
+	token, err := s.tokens.Token(ctx, s.auth, s.transport)
+	if err != nil {
+		return "", fmt.Errorf("oauth2 token: %w", err)
+	}
+	return token, nil
+}
+
+// Authorize sets bearer token of the source to the request, nil source leaves request as is
+func (s *tokenSource) Authorize(req *http.Request) error {
+	if s == nil {
+		return nil
+	}
+
+	token, err := s.Token(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func resolveOAuth2(creds *credentials, auth *kinds.OAuth2Auth) kinds.OAuth2Auth {
+	resolved := kinds.OAuth2Auth{
+		TokenURL:     creds.Apply("oauth2 tokenUrl", auth.TokenURL),
+		Grant:        auth.Grant,
+		ClientID:     creds.Apply("oauth2 clientId", auth.ClientID),
+		ClientSecret: creds.Apply("oauth2 clientSecret", auth.ClientSecret),
+		Username:     creds.Apply("oauth2 username", auth.Username),
+		Password:     creds.Apply("oauth2 password", auth.Password),
+		Scopes:       auth.Scopes,
+	}
+
+	if resolved.Grant == "" {
+		resolved.Grant = oauth2GrantClientCredentials
+	}
+	return resolved
+}
+
+type oauth2Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+
+	expiry time.Time
+}
+
+// valid reports whether token may still be sent, expiry is already moved back by the refresh skew
+func (t *oauth2Token) valid(now time.Time) bool {
+	return t != nil && t.AccessToken != "" && (t.expiry.IsZero() || now.Before(t.expiry))
+}
+
+// tokenCache keeps OAuth2 tokens per token endpoint, client and user, so cases reuse a token until it expires,
+// requests of the same token wait for each other while tokens of other clients are requested concurrently
+type tokenCache struct {
+	mx         sync.Mutex
+	transports *transports
+	entries    map[string]*tokenEntry
+	now        func() time.Time
+}
+
+// tokenEntry is the cached token of a single key, its lock is held while the token is requested
+type tokenEntry struct {
+	mx    sync.Mutex
+	token *oauth2Token
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{
+		transports: sharedTransports,
+		entries:    make(map[string]*tokenEntry),
+		now:        time.Now,
+	}
+}
+
+// Token returns cached access token, expired token is refreshed with its refresh token or requested again,
+// token endpoint is reached with the client of the transport, so TLS and proxy of the target apply
+func (c *tokenCache) Token(ctx context.Context, auth kinds.OAuth2Auth, transport transportConfig) (string, error) {
+	key := strings.Join([]string{auth.TokenURL, auth.Grant, auth.ClientID, auth.ClientSecret, auth.Username, auth.Password, strings.Join(auth.Scopes, " ")}, "|")
+
+	c.mx.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &tokenEntry{}
+		c.entries[key] = entry
+	}
+	c.mx.Unlock()
+
+	entry.mx.Lock()
+	defer entry.mx.Unlock()
+
+	cached := entry.token
+	if cached.valid(c.now()) {
+		return cached.AccessToken, nil
+	}
+
+	client, err := c.transports.Client(transport)
+	if err != nil {
+		return "", err
+	}
+
+	var token *oauth2Token
+
+	if cached != nil && cached.RefreshToken != "" {
+		token, _ = c.request(ctx, client, auth, url.Values{
+			"grant_type":    {oauth2GrantRefreshToken},
+			"refresh_token": {cached.RefreshToken},
+		})
+		if token != nil && token.RefreshToken == "" {
+			token.RefreshToken = cached.RefreshToken
+		}
+	}
+
+	if token == nil {
+		form := url.Values{"grant_type": {auth.Grant}}
+		if auth.Grant == oauth2GrantPassword {
+			form.Set("username", auth.Username)
+			form.Set("password", auth.Password)
+		}
+		if len(auth.Scopes) > 0 {
+			form.Set("scope", strings.Join(auth.Scopes, " "))
+		}
+
+		if token, err = c.request(ctx, client, auth, form); err != nil {
+			return "", err
+		}
+	}
+
+	entry.token = token
+	return token.AccessToken, nil
+}
+
+func (c *tokenCache) request(ctx context.Context, client *http.Client, auth kinds.OAuth2Auth, form url.Values) (*oauth2Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, oauth2RequestTimeout)
+	defer cancel()
+
+	form.Set("client_id", auth.ClientID)
+	if auth.ClientSecret != "" {
+		form.Set("client_secret", auth.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, auth.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create token request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", contentTypeForm)
+	req.Header.Set("Accept", contentTypeJSON)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, oauth2MaxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("read token response failed: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("token endpoint responded %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	token := &oauth2Token{}
+	if err = json.Unmarshal(body, token); err != nil {
+		return nil, fmt.Errorf("decode token response failed: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token endpoint responded without access_token")
+	}
+	if token.ExpiresIn > 0 {
+		lifetime := time.Duration(token.ExpiresIn) * time.Second
+		token.expiry = c.now().Add(lifetime - min(oauth2ExpirySkew, lifetime/oauth2ExpirySkewPart))
+	}
+
+	return token, nil
+}
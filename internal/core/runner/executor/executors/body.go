@@ -0,0 +1,159 @@
+package executors
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	"github.com/apiqube/cli/internal/core/runner/form"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+const (
+	bodyTypeJSON      = "json"
+	bodyTypeForm      = "form"
+	bodyTypeMultipart = "multipart"
+	bodyTypeRaw       = "raw"
+	bodyTypeBinary    = "binary"
+)
+
+const (
+	contentTypeJSON   = "application/json"
+	contentTypeForm   = "application/x-www-form-urlencoded"
+	contentTypeText   = "text/plain; charset=utf-8"
+	contentTypeXML    = "application/xml"
+	contentTypeBinary = "application/octet-stream"
+)
+
+// encodeBody encodes case body according to its bodyType and returns Content-Type matching the encoding,
+// relative file paths are resolved against directory of the manifest source
+func encodeBody(ctx interfaces.ExecutionContext, passer *form.Runner, c tests.HttpCase, source string) ([]byte, string, error) {
+	switch c.BodyType {
+	case "", bodyTypeJSON:
+		body := passer.ApplyBody(ctx, c.Body)
+		if body == nil {
+			return nil, "", nil
+		}
+
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, "", fmt.Errorf("encode json body failed: %w", err)
+		}
+		return data, contentTypeJSON, nil
+	case bodyTypeForm:
+		values := url.Values{}
+		for key, val := range passer.ApplyBody(ctx, c.Body) {
+			values[key] = formValues(val)
+		}
+		return []byte(values.Encode()), contentTypeForm, nil
+	case bodyTypeMultipart:
+		return encodeMultipart(ctx, passer, c, source)
+	case bodyTypeRaw:
+		raw := passer.Apply(ctx, c.Raw)
+		contentType := contentTypeText
+		if strings.HasPrefix(strings.TrimSpace(raw), "<") {
+			contentType = contentTypeXML
+		}
+		return []byte(raw), contentType, nil
+	case bodyTypeBinary:
+		path := manifestFilePath(source, passer.Apply(ctx, c.File))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("read body file failed: %w", err)
+		}
+
+		contentType := mime.TypeByExtension(filepath.Ext(path))
+		if contentType == "" {
+			contentType = contentTypeBinary
+		}
+		return data, contentType, nil
+	default:
+		return nil, "", fmt.Errorf("unknown body type %s", c.BodyType)
+	}
+}
+
+// encodeMultipart writes body fields and file parts, fields and files are written in sorted order
+func encodeMultipart(ctx interfaces.ExecutionContext, passer *form.Runner, c tests.HttpCase, source string) ([]byte, string, error) {
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+
+	fields := passer.ApplyBody(ctx, c.Body)
+	for _, key := range sortedKeys(fields) {
+		for _, val := range formValues(fields[key]) {
+			if err := writer.WriteField(key, val); err != nil {
+				return nil, "", fmt.Errorf("write multipart field %s failed: %w", key, err)
+			}
+		}
+	}
+
+	for _, field := range sortedKeys(c.Files) {
+		path := manifestFilePath(source, passer.Apply(ctx, c.Files[field]))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("read multipart file %s failed: %w", field, err)
+		}
+
+		part, err := writer.CreateFormFile(field, filepath.Base(path))
+		if err != nil {
+			return nil, "", fmt.Errorf("create multipart file %s failed: %w", field, err)
+		}
+		if _, err = part.Write(data); err != nil {
+			return nil, "", fmt.Errorf("write multipart file %s failed: %w", field, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("close multipart body failed: %w", err)
+	}
+
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+// formValues converts body value to form values, lists become repeated keys and objects are JSON encoded
+func formValues(val any) []string {
+	switch v := val.(type) {
+	case nil:
+		return []string{""}
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, formValues(item)...)
+		}
+		return values
+	case map[string]any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return []string{fmt.Sprint(v)}
+		}
+		return []string{string(data)}
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+func manifestFilePath(source, path string) string {
+	if filepath.IsAbs(path) || source == "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(source), path)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
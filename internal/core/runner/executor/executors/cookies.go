@@ -0,0 +1,133 @@
+package executors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	"github.com/apiqube/cli/internal/core/runner/form"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+const (
+	cookieJarManifest = "manifest"
+	cookieJarPlan     = "plan"
+)
+
+var _ http.CookieJar = (*sessionJar)(nil)
+
+// sessionJar is a cookie jar which cases may clear, cookies are kept between requests of all cases sharing it
+type sessionJar struct {
+	mx  sync.RWMutex
+	jar *cookiejar.Jar
+}
+
+func newSessionJar() *sessionJar {
+	// cookiejar.New fails only on invalid options
+	jar, _ := cookiejar.New(nil)
+	return &sessionJar{jar: jar}
+}
+
+func (j *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mx.RLock()
+	defer j.mx.RUnlock()
+	j.jar.SetCookies(u, cookies)
+}
+
+func (j *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mx.RLock()
+	defer j.mx.RUnlock()
+	return j.jar.Cookies(u)
+}
+
+func (j *sessionJar) Clear() {
+	j.mx.Lock()
+	defer j.mx.Unlock()
+	j.jar, _ = cookiejar.New(nil)
+}
+
+// planCookieJarKey stores plan wide jar in the execution context, so the jar lives exactly as long as the run
+const planCookieJarKey = "__executors.cookieJar.plan"
+
+// cookieJars hands out cookie jars of manifests, plan wide jar is shared by manifests of the same run
+type cookieJars struct {
+	mx sync.Mutex
+}
+
+func newCookieJars() *cookieJars {
+	return &cookieJars{}
+}
+
+// For returns jar of the manifest by its cookieJar scope, nil means cookies are not kept
+func (c *cookieJars) For(ctx interfaces.ExecutionContext, scope string) *sessionJar {
+	switch scope {
+	case cookieJarManifest:
+		return newSessionJar()
+	case cookieJarPlan:
+		c.mx.Lock()
+		defer c.mx.Unlock()
+
+		if val, ok := ctx.Get(planCookieJarKey); ok {
+			if jar, is := val.(*sessionJar); is {
+				return jar
+			}
+		}
+
+		jar := newSessionJar()
+		ctx.Set(planCookieJarKey, jar)
+		return jar
+	default:
+		return nil
+	}
+}
+
+// applyCaseCookies clears or seeds the jar before the case request, seeded values may contain templates
+func applyCaseCookies(ctx interfaces.ExecutionContext, passer *form.Runner, jar *sessionJar, rawURL string, cookies *tests.CaseCookies) error {
+	if cookies == nil {
+		return nil
+	}
+
+	if jar == nil {
+		return fmt.Errorf("case cookies require cookieJar to be enabled on the manifest")
+	}
+
+	if cookies.Clear {
+		jar.Clear()
+	}
+
+	if len(cookies.Set) == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("parse url %s failed: %w", rawURL, err)
+	}
+
+	seeded := make([]*http.Cookie, 0, len(cookies.Set))
+	for name, value := range cookies.Set {
+		seeded = append(seeded, &http.Cookie{Name: name, Value: passer.Apply(ctx, value), Path: "/"})
+	}
+	jar.SetCookies(u, seeded)
+
+	return nil
+}
+
+// inspectCookies returns cookies held by the jar for the url as sorted name=value pairs
+func inspectCookies(jar *sessionJar, u *url.URL) []string {
+	if jar == nil || u == nil {
+		return nil
+	}
+
+	cookies := jar.Cookies(u)
+	pairs := make([]string, 0, len(cookies))
+	for _, cookie := range cookies {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", cookie.Name, cookie.Value))
+	}
+	sort.Strings(pairs)
+	return pairs
+}
@@ -0,0 +1,28 @@
+package executors
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	"github.com/apiqube/cli/internal/core/runner/hooks"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+// runCaseHooks runs manifest hooks followed by case hooks of the event as a single batch,
+// so registered hook handlers are called only once per case
+func runCaseHooks(ctx interfaces.ExecutionContext, runner hooks.Runner, event hooks.HookEvent, scope hooks.Scope, manifestHooks, caseHooks *tests.HttpHooks) error {
+	return hooks.FromContext(ctx, runner).RunHooks(ctx, event, scope, slices.Concat(manifestHooks.Actions(event), caseHooks.Actions(event)))
+}
+
+// skipCase reports whether hooks asked to skip the case and marks case result as skipped
+func skipCase(err error, caseResult *interfaces.CaseResult) bool {
+	if !errors.Is(err, hooks.ErrSkip) {
+		return false
+	}
+
+	caseResult.Success = true
+	caseResult.Details["skipped"] = strings.TrimPrefix(strings.TrimPrefix(err.Error(), hooks.ErrSkip.Error()), ": ")
+	return true
+}
@@ -10,12 +10,12 @@ import (
 	"sync"
 	"time"
 
-	"github.com/goccy/go-json"
-
 	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
 	"github.com/apiqube/cli/internal/core/manifests/kinds/tests/api"
 	"github.com/apiqube/cli/internal/core/runner/assert"
 	"github.com/apiqube/cli/internal/core/runner/form"
+	"github.com/apiqube/cli/internal/core/runner/hooks"
 	"github.com/apiqube/cli/internal/core/runner/interfaces"
 	"github.com/apiqube/cli/internal/core/runner/metrics"
 	"github.com/apiqube/cli/internal/core/runner/save"
@@ -29,21 +29,34 @@ const (
 var _ interfaces.Executor = (*HTTPExecutor)(nil)
 
 type HTTPExecutor struct {
-	client    *http.Client
-	extractor *save.Extractor
-	assertor  *assert.Runner
-	passer    *form.Runner
+	extractor  *save.Extractor
+	assertor   *assert.Runner
+	passer     *form.Runner
+	hooks      hooks.Runner // Used when the plan runner has not shared its own, see hooks.FromContext
+	jars       *cookieJars
+	tokens     *tokenCache
+	transports *transports
+	signers    *signers
 }
 
 func NewHTTPExecutor() *HTTPExecutor {
 	return &HTTPExecutor{
-		client:    &http.Client{Timeout: httpExecutorRunTimeout},
-		extractor: save.NewExtractor(),
-		assertor:  assert.NewRunner(),
-		passer:    form.NewRunner(),
+		extractor:  save.NewExtractor(),
+		assertor:   assert.NewRunner(),
+		passer:     form.NewRunner(),
+		hooks:      hooks.NewDefaultHooksRunner(),
+		jars:       newCookieJars(),
+		tokens:     newTokenCache(),
+		transports: sharedTransports,
+		signers:    newSigners(),
 	}
 }
 
+// RegisterSigner makes custom signer available to manifests by name, see kinds.CustomSigning
+func (e *HTTPExecutor) RegisterSigner(name string, factory SignerFactory) {
+	e.signers.Register(name, factory)
+}
+
 func (e *HTTPExecutor) Run(ctx interfaces.ExecutionContext, manifest manifests.Manifest) error {
 	select {
 	case <-ctx.Done():
@@ -56,6 +69,8 @@ func (e *HTTPExecutor) Run(ctx interfaces.ExecutionContext, manifest manifests.M
 		return fmt.Errorf("%s manifest %s is not a %s kind", httpExecutorOutputPrefix, manifest.GetID(), manifests.HttpTestKind)
 	}
 
+	jar := e.jars.For(ctx, httpMan.Spec.CookieJar)
+
 	var wg sync.WaitGroup
 	errCh := make(chan error, len(httpMan.Spec.Cases))
 
@@ -65,12 +80,12 @@ func (e *HTTPExecutor) Run(ctx interfaces.ExecutionContext, manifest manifests.M
 			wg.Add(1)
 			go func(tc api.HttpCase) {
 				defer wg.Done()
-				if err := e.runCase(ctx, httpMan, tc); err != nil {
+				if err := e.runCase(ctx, httpMan, tc, jar); err != nil {
 					errCh <- err
 				}
 			}(testCase)
 		} else {
-			if err := e.runCase(ctx, httpMan, testCase); err != nil {
+			if err := e.runCase(ctx, httpMan, testCase, jar); err != nil {
 				return err
 			}
 		}
@@ -89,7 +104,7 @@ func (e *HTTPExecutor) Run(ctx interfaces.ExecutionContext, manifest manifests.M
 	return nil
 }
 
-func (e *HTTPExecutor) runCase(ctx interfaces.ExecutionContext, man *api.Http, c api.HttpCase) error {
+func (e *HTTPExecutor) runCase(ctx interfaces.ExecutionContext, man *api.Http, c api.HttpCase, jar *sessionJar) (rErr error) {
 	output := ctx.GetOutput()
 
 	caseResult := &interfaces.CaseResult{
@@ -101,78 +116,263 @@ func (e *HTTPExecutor) runCase(ctx interfaces.ExecutionContext, man *api.Http, c
 	var (
 		req      *http.Request
 		resp     *http.Response
-		reqBody  = &bytes.Buffer{}
 		respBody = &bytes.Buffer{}
 		err      error
 	)
 	var reqBodyCopy []byte
 
+	scope := hooks.Scope{ManifestID: man.GetID(), Case: c.Name}
+
 	output.StartCase(man, c.Name)
 	defer func() {
+		if rErr != nil {
+			scope.Err = rErr
+			if err := runCaseHooks(ctx, e.hooks, hooks.OnFailure, scope, man.Spec.Hooks, c.Hooks); err != nil {
+				caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("on failure hooks failed: %s", err.Error()))
+				rErr = errors.Join(rErr, err)
+			}
+		}
+
 		metrics.CollectHTTPMetrics(req, resp, c.Details, caseResult)
 		e.extractor.Extract(ctx, man, c.HttpCase, resp, reqBodyCopy, respBody.Bytes(), caseResult)
+		e.extractor.SaveAlias(ctx, c.HttpCase, resp, reqBodyCopy, respBody.Bytes())
 
 		output.EndCase(man, c.Name, caseResult)
 	}()
 
-	url := buildHttpURL(c.Url, man.Spec.Target, c.Endpoint)
-	url = e.passer.Apply(ctx, url)
-	headers := e.passer.MapHeaders(ctx, c.Headers)
-	body := e.passer.ApplyBody(ctx, c.Body)
-
-	if body != nil {
-		if err = json.NewEncoder(reqBody).Encode(body); err != nil {
-			caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to encode request body: %s", err.Error()))
-			return fmt.Errorf("encode body failed: %w", err)
+	if err = runCaseHooks(ctx, e.hooks, hooks.BeforeRequest, scope, man.Spec.Hooks, c.Hooks); err != nil {
+		if skipCase(err, caseResult) {
+			output.Logf(interfaces.WarnLevel, "%s HTTP Test %s skipped by before request hooks", httpExecutorOutputPrefix, c.Name)
+			return nil
 		}
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("before request hooks failed: %s", err.Error()))
+		return fmt.Errorf("before request hooks failed: %w", err)
+	}
+
+	opts := caseOptions{
+		auth: man.Spec.Auth,
+		transport: transportConfig{
+			tls:      man.Spec.TLS,
+			source:   man.GetMeta().GetSource(),
+			settings: man.Spec.Transport,
+		},
 	}
 
-	reqBodyCopy = reqBody.Bytes()
-	req, err = http.NewRequest(c.Method, url, reqBody)
+	caseReq, err := resolveCaseRequest(ctx, e.passer, e.tokens, man.GetNamespace(), man.Spec.Target, opts, c.HttpCase)
 	if err != nil {
-		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to create request: %s", err.Error()))
-		return fmt.Errorf("create request failed: %w", err)
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to resolve target: %s", err.Error()))
+		return fmt.Errorf("resolve target failed: %w", err)
+	}
+	reqBodyCopy, contentType, err := encodeBody(ctx, e.passer, c.HttpCase, man.GetMeta().GetSource())
+	if err != nil {
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to encode request body: %s", err.Error()))
+		return fmt.Errorf("encode body failed: %w", err)
 	}
 
-	for k, v := range headers {
-		req.Header.Set(k, v)
+	url, headers := caseReq.url, caseReq.headers
+	if err = applyCaseCookies(ctx, e.passer, jar, url, c.Cookies); err != nil {
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to apply cookies: %s", err.Error()))
+		return fmt.Errorf("apply cookies failed: %w", err)
 	}
 
-	client := e.client
+	timeout := httpExecutorRunTimeout
 	if c.Timeout > 0 {
-		client = &http.Client{Timeout: c.Timeout}
+		timeout = c.Timeout
 		caseResult.Details["timeout"] = c.Timeout
 	}
 
-	start := time.Now()
-	resp, err = client.Do(req)
-	caseResult.Duration = time.Since(start)
+	client, err := e.transports.Client(caseReq.transport)
+	if err != nil {
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to configure transport: %s", err.Error()))
+		return fmt.Errorf("configure transport failed: %w", err)
+	}
+	if jar != nil {
+		// Copy keeps transport and connections of the shared client, jar belongs to the manifest or plan
+		withJar := *client
+		withJar.Jar = jar
+		client = &withJar
+	}
+
+	signer, err := e.signers.New(ctx, e.passer, man.Spec.Signing)
+	if err != nil {
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to configure signing: %s", err.Error()))
+		return fmt.Errorf("configure signing failed: %w", err)
+	}
+	policy := newRetryPolicy(c.Retry)
+	files := assert.Files{
+		Source:   man.GetMeta().GetSource(),
+		Snapshot: assert.SnapshotPath(man.GetMeta().GetSource(), man.GetNamespace(), man.GetName(), c.Name),
+	}
+
+	var condition *pollCondition
+	if c.Poll != nil {
+		if condition, err = parsePollCondition(e.passer.Apply(ctx, c.Poll.Until)); err != nil {
+			caseResult.Errors = append(caseResult.Errors, err.Error())
+			return fmt.Errorf("poll condition failed: %w", err)
+		}
+	}
+
+	var (
+		attempts  []httpAttempt
+		pollErr   error
+		pollStart = time.Now()
+
+		// Results of the last retry attempt are reused, only snapshots are checked after the loop
+		regularAsserts, snapshotAsserts = assert.SplitSnapshots(c.Assert)
+		attemptAsserts                  []*interfaces.AssertResult
+		attemptAssertErr                error
+		attemptEvaluated                bool
+	)
+
+	for attempt := 1; ; attempt++ {
+		attemptEvaluated = false
+		// Request context is derived from the run, so cancellation of the run aborts request in flight
+		reqCtx, cancel := context.WithTimeout(ctx, timeout)
+		req, err = http.NewRequestWithContext(reqCtx, c.Method, url, bytes.NewReader(reqBodyCopy))
+		if err != nil {
+			cancel()
+			caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to create request: %s", err.Error()))
+			return fmt.Errorf("create request failed: %w", err)
+		}
+
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		if err = caseReq.token.Authorize(req); err != nil {
+			cancel()
+			caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to authorize request: %s", err.Error()))
+			return fmt.Errorf("authorize request failed: %w", err)
+		}
+
+		if signer != nil {
+			if err = signer.Sign(req, reqBodyCopy); err != nil {
+				cancel()
+				caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to sign request: %s", err.Error()))
+				return fmt.Errorf("sign request failed: %w", err)
+			}
+		}
+
+		respBody.Reset()
+		start := time.Now()
+		resp, err = client.Do(req)
+		if err == nil {
+			if _, err = respBody.ReadFrom(resp.Body); err != nil {
+				err = fmt.Errorf("read response body failed: %w", err)
+			}
+			if closeErr := resp.Body.Close(); closeErr != nil {
+				caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to close response body: %s", closeErr.Error()))
+				output.Logf(interfaces.ErrorLevel, "%s %s response body close failed\nTarget: %s\nName: %s\nMethod: %s\nReason: %s", httpExecutorOutputPrefix, man.GetName(), man.Spec.Target, c.Name, c.Method, closeErr.Error())
+			}
+		}
+		caseResult.Duration = time.Since(start)
+		cancel()
+
+		if condition == nil && policy.Attempts() == 1 {
+			break
+		}
+
+		record := httpAttempt{Attempt: attempt, Duration: caseResult.Duration}
+		if err != nil {
+			record.Error = err.Error()
+		} else {
+			record.StatusCode = resp.StatusCode
+		}
+		attempts = append(attempts, record)
+
+		var delay time.Duration
+		if condition != nil {
+			// Polling goes on through request errors until condition holds or deadline passes
+			if err == nil && condition.Match(respBody.Bytes()) {
+				break
+			}
+
+			delay = c.Poll.Interval
+			if delay <= 0 {
+				delay = pollDefaultInterval
+			}
+
+			if time.Since(pollStart)+delay > c.Poll.Timeout {
+				pollErr = fmt.Errorf("poll condition %q was not met within %s after %d attempts", c.Poll.Until, c.Poll.Timeout, attempt)
+				break
+			}
+		} else {
+			if err == nil && c.Assert != nil {
+				attemptAsserts, attemptAssertErr = e.assertor.Evaluate(ctx, regularAsserts, resp, respBody.Bytes(), caseResult.Duration, assert.Files{Source: files.Source})
+				attemptEvaluated = true
+			}
+
+			if attempt >= policy.Attempts() || !policy.ShouldRetry(record.StatusCode, err, attemptAssertErr) {
+				break
+			}
+
+			delay = policy.Delay(attempt)
+			output.Logf(interfaces.WarnLevel, "%s HTTP Test %s attempt %d of %d failed, retrying in %s", httpExecutorOutputPrefix, c.Name, attempt, policy.Attempts(), delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			caseResult.Details["attempts"] = attempts
+			caseResult.Errors = append(caseResult.Errors, "repeating request was canceled")
+			return fmt.Errorf("repeating %s request canceled: %w", c.Name, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+
+	if len(attempts) > 0 {
+		caseResult.Details["attempts"] = attempts
+	}
+
 	if err != nil {
+		if ctx.Err() != nil {
+			caseResult.Errors = append(caseResult.Errors, "request was canceled")
+			return fmt.Errorf("request to %s canceled: %w", url, ctx.Err())
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			caseResult.Errors = append(caseResult.Errors, "request timed out")
 			return fmt.Errorf("request to %s timed out", url)
 		}
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("request failed: %s", err.Error()))
 		return fmt.Errorf("http request failed: %w", err)
 	}
 
-	defer func() {
-		if err = resp.Body.Close(); err != nil {
-			caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to close response body: %s", err.Error()))
-			output.Logf(interfaces.ErrorLevel, "%s %s response body close failed\nTarget: %s\nName: %s\nMethod: %s\nReason: %s", httpExecutorOutputPrefix, man.GetName(), man.Spec.Target, c.Name, c.Method, err.Error())
-		}
-	}()
+	if pollErr != nil {
+		caseResult.Errors = append(caseResult.Errors, pollErr.Error())
+		return pollErr
+	}
 
-	_, err = respBody.ReadFrom(resp.Body)
-	if err != nil {
-		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("failed to read response body: %s", err.Error()))
-		return fmt.Errorf("read response body failed: %w", err)
+	if c.Cookies != nil && c.Cookies.Inspect {
+		caseResult.Details["cookies"] = inspectCookies(jar, req.URL)
+	}
+
+	if err = runCaseHooks(ctx, e.hooks, hooks.AfterResponse, scope, man.Spec.Hooks, c.Hooks); err != nil {
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("after response hooks failed: %s", err.Error()))
+		return fmt.Errorf("after response hooks failed: %w", err)
 	}
 
 	if c.Assert != nil {
 		output.Logf(interfaces.InfoLevel, "%s response asserting for %s %s", httpExecutorOutputPrefix, man.GetName(), c.Name)
-		if err = e.assertor.Assert(ctx, c.Assert, resp, respBody.Bytes()); err != nil {
+		if attemptEvaluated {
+			snapshotResults, snapshotErr := e.assertor.Evaluate(ctx, snapshotAsserts, resp, respBody.Bytes(), caseResult.Duration, files)
+			caseResult.Asserts = mergeAssertResults(c.Assert, attemptAsserts, snapshotResults)
+			err = errors.Join(attemptAssertErr, snapshotErr)
+		} else {
+			caseResult.Asserts, err = e.assertor.Evaluate(ctx, c.Assert, resp, respBody.Bytes(), caseResult.Duration, files)
+		}
+		for _, result := range caseResult.Asserts {
+			if result.Soft && !result.Passed {
+				output.Logf(interfaces.WarnLevel, "%s HTTP Test %s soft assertion %s failed: %s", httpExecutorOutputPrefix, c.Name, result.Name, result.Error)
+			}
+		}
+
+		if err != nil {
 			caseResult.Assert = "no"
-			caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("assertion failed: %s", err.Error()))
+			for _, assertErr := range assert.Flatten(err) {
+				caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("assertion failed: %s", assertErr.Error()))
+			}
 			return fmt.Errorf("assert failed: %w", err)
 		}
 		caseResult.Assert = "yes"
@@ -184,6 +384,19 @@ func (e *HTTPExecutor) runCase(ctx interfaces.ExecutionContext, man *api.Http, c
 	return nil
 }
 
+// mergeAssertResults restores declared order of assertions evaluated separately from snapshot ones
+func mergeAssertResults(asserts []*tests.Assert, regular, snapshots []*interfaces.AssertResult) []*interfaces.AssertResult {
+	results := make([]*interfaces.AssertResult, 0, len(regular)+len(snapshots))
+	for _, a := range asserts {
+		if a.Target == assert.Snapshot.String() && len(snapshots) > 0 {
+			results, snapshots = append(results, snapshots[0]), snapshots[1:]
+		} else if a.Target != assert.Snapshot.String() && len(regular) > 0 {
+			results, regular = append(results, regular[0]), regular[1:]
+		}
+	}
+	return results
+}
+
 func buildHttpURL(url, target, endpoint string) string {
 	if url == "" {
 		baseUrl := strings.TrimRight(target, "/")
@@ -0,0 +1,1251 @@
+package executors
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/servers"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/services"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests/api"
+	"github.com/apiqube/cli/internal/core/runner/assert"
+	runctx "github.com/apiqube/cli/internal/core/runner/context"
+	"github.com/apiqube/cli/internal/core/runner/form"
+	"github.com/apiqube/cli/internal/core/runner/hooks"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+func newHttpManifest(target string, cases ...api.HttpCase) *api.Http {
+	man := &api.Http{
+		BaseManifest: kinds.BaseManifest{
+			Version: manifests.V1,
+			Kind:    manifests.HttpTestKind,
+			Metadata: kinds.Metadata{
+				Name:      "http-test",
+				Namespace: manifests.DefaultNamespace,
+			},
+		},
+	}
+	man.Spec.Target = target
+	man.Spec.Cases = cases
+	man.Default()
+	return man
+}
+
+func TestHTTPExecutorCaseHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Seed") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	saveAction := func(key, value string) hooks.Action {
+		return hooks.Action{Type: hooks.ActionSave, Params: map[string]any{"key": key, "value": value}}
+	}
+
+	t.Run("manifest and case hooks run around request", func(t *testing.T) {
+		exec := NewHTTPExecutor()
+
+		var calls []string
+		exec.hooks.RegisterHooksHandler(hooks.BeforeRequest, func(_ interfaces.ExecutionContext, event hooks.HookEvent, scope hooks.Scope) error {
+			calls = append(calls, scope.Level()+" "+scope.Case+" "+event.String())
+			return nil
+		})
+
+		c := api.HttpCase{HttpCase: tests.HttpCase{
+			Name:    "seeded",
+			Method:  http.MethodGet,
+			Headers: map[string]string{"X-Seed": "{{ seed }}"},
+			Assert:  []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+			Hooks:   &tests.HttpHooks{BeforeRequest: []hooks.Action{saveAction("seed", "{{ prefix }}-42")}},
+		}}
+
+		man := newHttpManifest(server.URL, c)
+		man.Spec.Hooks = &tests.HttpHooks{
+			BeforeRequest: []hooks.Action{saveAction("prefix", "user")},
+			AfterResponse: []hooks.Action{saveAction("after", "done")},
+		}
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.NoError(t, exec.Run(ctx, man))
+
+		seed, _ := ctx.Get("seed")
+		require.Equal(t, "user-42", seed)
+		after, _ := ctx.Get("after")
+		require.Equal(t, "done", after)
+		require.Equal(t, []string{"case seeded before request"}, calls)
+	})
+
+	t.Run("on failure hooks receive case error", func(t *testing.T) {
+		exec := NewHTTPExecutor()
+
+		var failed hooks.Scope
+		exec.hooks.RegisterHooksHandler(hooks.OnFailure, func(_ interfaces.ExecutionContext, _ hooks.HookEvent, scope hooks.Scope) error {
+			failed = scope
+			return nil
+		})
+
+		man := newHttpManifest(server.URL, api.HttpCase{HttpCase: tests.HttpCase{
+			Name:   "unseeded",
+			Method: http.MethodGet,
+			Assert: []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+			Hooks:  &tests.HttpHooks{OnFailure: []hooks.Action{saveAction("diagnostics", "dumped")}},
+		}})
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.Error(t, exec.Run(ctx, man))
+
+		dumped, _ := ctx.Get("diagnostics")
+		require.Equal(t, "dumped", dumped)
+		require.Equal(t, "unseeded", failed.Case)
+		require.Equal(t, man.GetID(), failed.ManifestID)
+		require.Error(t, failed.Err)
+	})
+
+	t.Run("skip before request skips only that case", func(t *testing.T) {
+		exec := NewHTTPExecutor()
+
+		man := newHttpManifest(server.URL, api.HttpCase{HttpCase: tests.HttpCase{
+			Name:   "skipped",
+			Method: http.MethodGet,
+			Assert: []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+			Hooks: &tests.HttpHooks{BeforeRequest: []hooks.Action{
+				{Type: hooks.ActionSkip, Params: map[string]any{"reason": "not ready"}},
+			}},
+		}})
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.NoError(t, exec.Run(ctx, man))
+	})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("no retry configured", func(t *testing.T) {
+		policy := newRetryPolicy(nil)
+		require.Equal(t, 1, policy.Attempts())
+		require.False(t, policy.ShouldRetry(0, errors.New("refused"), nil))
+	})
+
+	t.Run("conditions", func(t *testing.T) {
+		anyFailure := newRetryPolicy(&tests.Retry{Attempts: 3})
+		require.True(t, anyFailure.ShouldRetry(0, errors.New("refused"), nil))
+		require.True(t, anyFailure.ShouldRetry(http.StatusOK, nil, errors.New("assert")))
+		require.False(t, anyFailure.ShouldRetry(http.StatusOK, nil, nil))
+
+		status := newRetryPolicy(&tests.Retry{Attempts: 3, OnStatus: []int{http.StatusServiceUnavailable}})
+		require.True(t, status.ShouldRetry(http.StatusServiceUnavailable, nil, nil))
+		require.False(t, status.ShouldRetry(http.StatusOK, nil, errors.New("assert")))
+		require.False(t, status.ShouldRetry(0, errors.New("refused"), nil))
+
+		network := newRetryPolicy(&tests.Retry{Attempts: 3, OnError: true, UntilAssert: true})
+		require.True(t, network.ShouldRetry(0, errors.New("refused"), nil))
+		require.True(t, network.ShouldRetry(http.StatusOK, nil, errors.New("assert")))
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		fixed := newRetryPolicy(&tests.Retry{Attempts: 5, Delay: time.Millisecond * 100})
+		require.Equal(t, time.Millisecond*100, fixed.Delay(3))
+
+		exponential := newRetryPolicy(&tests.Retry{Attempts: 5, Backoff: retryBackoffExponential, Delay: time.Millisecond * 100, MaxDelay: time.Millisecond * 300})
+		require.Equal(t, time.Millisecond*100, exponential.Delay(1))
+		require.Equal(t, time.Millisecond*200, exponential.Delay(2))
+		require.Equal(t, time.Millisecond*300, exponential.Delay(3))
+
+		unbounded := newRetryPolicy(&tests.Retry{Attempts: 100, Backoff: retryBackoffExponential, Delay: time.Millisecond * 100})
+		for attempt := 1; attempt < 100; attempt++ {
+			delay := unbounded.Delay(attempt)
+			require.Positive(t, delay)
+			require.LessOrEqual(t, delay, retryDefaultMaxDelay)
+		}
+		require.Equal(t, retryDefaultMaxDelay, unbounded.Delay(99))
+
+		jitter := newRetryPolicy(&tests.Retry{Attempts: 5, Delay: time.Millisecond * 100, Jitter: 0.5})
+		for i := 0; i < 10; i++ {
+			delay := jitter.Delay(1)
+			require.GreaterOrEqual(t, delay, time.Millisecond*100)
+			require.LessOrEqual(t, delay, time.Millisecond*150)
+		}
+	})
+}
+
+func TestHTTPExecutorRetry(t *testing.T) {
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	newCase := func(retry *tests.Retry) api.HttpCase {
+		return api.HttpCase{HttpCase: tests.HttpCase{
+			Name:   "eventually ready",
+			Method: http.MethodGet,
+			Assert: []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+			Retry:  retry,
+		}}
+	}
+
+	t.Run("retries until success", func(t *testing.T) {
+		hits.Store(0)
+		man := newHttpManifest(server.URL, newCase(&tests.Retry{Attempts: 5, Delay: time.Millisecond, OnStatus: []int{http.StatusServiceUnavailable}}))
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+		require.EqualValues(t, 3, hits.Load())
+	})
+
+	t.Run("stops after max attempts", func(t *testing.T) {
+		hits.Store(0)
+		man := newHttpManifest(server.URL, newCase(&tests.Retry{Attempts: 2, Delay: time.Millisecond, UntilAssert: true}))
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.Error(t, NewHTTPExecutor().Run(ctx, man))
+		require.EqualValues(t, 2, hits.Load())
+	})
+}
+
+func TestPollCondition(t *testing.T) {
+	body := []byte(`{"status":"done","progress":100,"ready":true,"error":null,"items":[{"id":1}]}`)
+
+	for expr, expected := range map[string]bool{
+		`status == "done"`:       true,
+		`$.status == "done"`:     true,
+		`status != "done"`:       false,
+		`progress >= 100`:        true,
+		`progress < 50`:          false,
+		`ready`:                  true,
+		`ready == true`:          true,
+		`error == null`:          true,
+		`error`:                  false,
+		`missing`:                false,
+		`items.#(id==1).id == 1`: true,
+	} {
+		cond, err := parsePollCondition(expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, expected, cond.Match(body), expr)
+	}
+
+	_, err := parsePollCondition(`status > "done"`)
+	require.Error(t, err)
+}
+
+func TestHTTPExecutorPoll(t *testing.T) {
+	var polls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jobs/1":
+			status := "running"
+			if polls.Add(1) >= 3 {
+				status = "done"
+			}
+			_, _ = fmt.Fprintf(w, `{"id":1,"status":%q,"result":{"count":42}}`, status)
+		case "/results/42":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	alias := "job"
+
+	t.Run("polls until condition and saves aliased result", func(t *testing.T) {
+		polls.Store(0)
+		man := newHttpManifest(server.URL,
+			api.HttpCase{HttpCase: tests.HttpCase{
+				Name:     "wait for job",
+				Alias:    &alias,
+				Method:   http.MethodGet,
+				Endpoint: "/jobs/1",
+				Poll:     &tests.Poll{Until: `status == "done"`, Interval: time.Millisecond, Timeout: time.Second},
+			}},
+			api.HttpCase{HttpCase: tests.HttpCase{
+				Name:     "fetch job result",
+				Method:   http.MethodGet,
+				Endpoint: "/results/{{ job.response.body.result.count }}",
+				Assert:   []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+			}},
+		)
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+		require.EqualValues(t, 3, polls.Load())
+	})
+
+	t.Run("fails when deadline passes", func(t *testing.T) {
+		polls.Store(-100)
+		man := newHttpManifest(server.URL, api.HttpCase{HttpCase: tests.HttpCase{
+			Name:     "never done",
+			Method:   http.MethodGet,
+			Endpoint: "/jobs/1",
+			Poll:     &tests.Poll{Until: `status == "done"`, Interval: time.Millisecond * 10, Timeout: time.Millisecond * 50},
+		}})
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		err := NewHTTPExecutor().Run(ctx, man)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "was not met within")
+	})
+}
+
+func newServerManifest(name, namespace, baseURL string, headers map[string]string) *servers.Server {
+	server := &servers.Server{
+		BaseManifest: kinds.BaseManifest{
+			Version:  manifests.V1,
+			Kind:     manifests.ServerKind,
+			Metadata: kinds.Metadata{Name: name, Namespace: namespace},
+		},
+	}
+	server.Spec.BaseURL = baseURL
+	server.Spec.Headers = headers
+	server.Default()
+	return server
+}
+
+func TestResolveTarget(t *testing.T) {
+	server := newServerManifest("api", manifests.DefaultNamespace, "http://api.local", map[string]string{"X-Env": "test"})
+	other := newServerManifest("api", "staging", "http://staging.local", nil)
+
+	service := &services.Service{
+		BaseManifest: kinds.BaseManifest{
+			Version:  manifests.V1,
+			Kind:     manifests.ServiceKind,
+			Metadata: kinds.Metadata{Name: "backend", Namespace: manifests.DefaultNamespace},
+		},
+	}
+	service.Spec.Containers = []services.Container{
+		{Name: "users-service", Ports: []string{"8080:80"}},
+		{Name: "auth-service", ContainerName: "auth", Ports: []string{"9090:90"}},
+		{Name: "admin-service", ContainerName: "admin", Ports: []string{"127.0.0.1:7070:70"}},
+		{Name: "metrics-service", ContainerName: "metrics", Ports: []string{"6060:60/tcp"}},
+	}
+	service.Default()
+
+	ctx := runctx.NewCtxBuilder().WithManifests(server, other, service).Build()
+
+	for target, expected := range map[string]string{
+		"http://literal.local":    "http://literal.local",
+		"api":                     "http://api.local",
+		other.GetID():             "http://staging.local",
+		"backend":                 "http://localhost:8080",
+		"backend/auth":            "http://localhost:9090",
+		service.GetID() + "/auth": "http://localhost:9090",
+		"backend/admin":           "http://localhost:7070",
+		"backend/metrics":         "http://localhost:6060",
+		"unknown":                 "unknown",
+	} {
+		resolved, err := resolveTarget(ctx, manifests.DefaultNamespace, target)
+		require.NoError(t, err, target)
+		require.Equal(t, expected, resolved.baseURL, target)
+	}
+
+	resolved, err := resolveTarget(ctx, manifests.DefaultNamespace, "api")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"X-Env": "test"}, resolved.headers)
+
+	_, err = resolveTarget(ctx, "production", "api")
+	require.Error(t, err)
+
+	_, err = resolveTarget(ctx, manifests.DefaultNamespace, "backend/missing")
+	require.Error(t, err)
+
+	for mapping, expected := range map[string]string{
+		"8080":                "8080",
+		"8080/udp":            "8080",
+		"8080:80":             "8080",
+		"8080:80/tcp":         "8080",
+		"127.0.0.1:8080:80":   "8080",
+		"[::1]:8080:80/tcp":   "8080",
+		"127.0.0.1::80":       "",
+		"0.0.0.0:9000:90/udp": "9000",
+	} {
+		hostPort, err := publishedHostPort(mapping)
+		if expected == "" {
+			require.Error(t, err, mapping)
+			continue
+		}
+		require.NoError(t, err, mapping)
+		require.Equal(t, expected, hostPort, mapping)
+	}
+}
+
+func TestHTTPExecutorServerTarget(t *testing.T) {
+	var got http.Header
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	server := newServerManifest("backend", manifests.DefaultNamespace, backend.URL, map[string]string{
+		"X-Env":        "test",
+		"Content-Type": "application/json",
+	})
+
+	man := newHttpManifest(server.GetName(), api.HttpCase{HttpCase: tests.HttpCase{
+		Name:     "uses server defaults",
+		Method:   http.MethodGet,
+		Endpoint: "/ping",
+		Headers:  map[string]string{"content-type": "text/plain"},
+		Assert:   []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+	}})
+
+	ctx := runctx.NewCtxBuilder().WithManifests(server, man).Build()
+	require.NoError(t, NewServerExecutor().Run(ctx, server))
+	require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+
+	require.Equal(t, "test", got.Get("X-Env"))
+	require.Equal(t, "text/plain", got.Get("Content-Type"))
+}
+
+func TestHTTPExecutorSoftAssert(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"degraded"}`))
+	}))
+	defer server.Close()
+
+	newCase := func(soft bool) api.HttpCase {
+		return api.HttpCase{HttpCase: tests.HttpCase{
+			Name:   "health",
+			Method: http.MethodGet,
+			Assert: []*tests.Assert{
+				{Target: "status", Equals: http.StatusOK},
+				{Target: "body", Path: "status", Equals: "ok", Soft: soft},
+			},
+		}}
+	}
+
+	man := newHttpManifest(server.URL, newCase(true))
+	ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+	require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+
+	man = newHttpManifest(server.URL, newCase(false))
+	ctx = runctx.NewCtxBuilder().WithManifests(man).Build()
+	require.Error(t, NewHTTPExecutor().Run(ctx, man))
+}
+
+func TestHTTPExecutorCookieJar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t", Path: "/", HttpOnly: true})
+		case "/me":
+			if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "s3cr3t" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}
+	}))
+	defer server.Close()
+
+	newCase := func(name, endpoint string, status int, cookies *tests.CaseCookies) api.HttpCase {
+		return api.HttpCase{HttpCase: tests.HttpCase{
+			Name:     name,
+			Method:   http.MethodGet,
+			Endpoint: endpoint,
+			Assert:   []*tests.Assert{{Target: "status", Equals: status}},
+			Cookies:  cookies,
+		}}
+	}
+
+	t.Run("manifest jar keeps session", func(t *testing.T) {
+		man := newHttpManifest(server.URL,
+			newCase("login", "/login", http.StatusOK, nil),
+			newCase("me", "/me", http.StatusOK, &tests.CaseCookies{Inspect: true}),
+			newCase("me after clear", "/me", http.StatusUnauthorized, &tests.CaseCookies{Clear: true}),
+			newCase("me with seeded", "/me", http.StatusOK, &tests.CaseCookies{Set: map[string]string{"session": "{{ token }}"}}),
+		)
+		man.Spec.CookieJar = cookieJarManifest
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		ctx.Set("token", "s3cr3t")
+		require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+	})
+
+	t.Run("no jar by default", func(t *testing.T) {
+		man := newHttpManifest(server.URL,
+			newCase("login", "/login", http.StatusOK, nil),
+			newCase("me", "/me", http.StatusUnauthorized, nil),
+		)
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+
+		man = newHttpManifest(server.URL, newCase("me", "/me", http.StatusOK, &tests.CaseCookies{Clear: true}))
+		require.ErrorContains(t, NewHTTPExecutor().Run(ctx, man), "cookieJar")
+	})
+
+	t.Run("plan jar is shared between manifests", func(t *testing.T) {
+		login := newHttpManifest(server.URL, newCase("login", "/login", http.StatusOK, nil))
+		login.Spec.CookieJar = cookieJarPlan
+		me := newHttpManifest(server.URL, newCase("me", "/me", http.StatusOK, nil))
+		me.Spec.CookieJar = cookieJarPlan
+
+		executor := NewHTTPExecutor()
+		ctx := runctx.NewCtxBuilder().WithManifests(login, me).Build()
+		require.NoError(t, executor.Run(ctx, login))
+		require.NoError(t, executor.Run(ctx, me))
+
+		jar, ok := ctx.Get(planCookieJarKey)
+		require.True(t, ok)
+		require.Same(t, jar, executor.jars.For(ctx, cookieJarPlan))
+
+		other := runctx.NewCtxBuilder().WithManifests(me).Build()
+		require.Error(t, executor.Run(other, me))
+	})
+}
+
+func TestEncodeBody(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "http_test.yaml")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "avatar.png"), []byte("png-bytes"), 0o644))
+
+	ctx := runctx.NewCtxBuilder().Build()
+	ctx.Set("user", "alice")
+	passer := form.NewRunner()
+
+	data, contentType, err := encodeBody(ctx, passer, tests.HttpCase{Body: map[string]any{"name": "{{ user }}"}}, source)
+	require.NoError(t, err)
+	require.Equal(t, "application/json", contentType)
+	require.JSONEq(t, `{"name":"alice"}`, string(data))
+
+	data, contentType, err = encodeBody(ctx, passer, tests.HttpCase{}, source)
+	require.NoError(t, err)
+	require.Empty(t, contentType)
+	require.Empty(t, data)
+
+	data, contentType, err = encodeBody(ctx, passer, tests.HttpCase{
+		BodyType: "form",
+		Body:     map[string]any{"name": "{{ user }}", "tags": []any{"a", "b"}, "age": 30},
+	}, source)
+	require.NoError(t, err)
+	require.Equal(t, "application/x-www-form-urlencoded", contentType)
+	require.Equal(t, "age=30&name=alice&tags=a&tags=b", string(data))
+
+	data, contentType, err = encodeBody(ctx, passer, tests.HttpCase{BodyType: "raw", Raw: "<user>{{ user }}</user>"}, source)
+	require.NoError(t, err)
+	require.Equal(t, "application/xml", contentType)
+	require.Equal(t, "<user>alice</user>", string(data))
+
+	_, contentType, err = encodeBody(ctx, passer, tests.HttpCase{BodyType: "raw", Raw: "hello"}, source)
+	require.NoError(t, err)
+	require.Equal(t, "text/plain; charset=utf-8", contentType)
+
+	data, contentType, err = encodeBody(ctx, passer, tests.HttpCase{BodyType: "binary", File: "avatar.png"}, source)
+	require.NoError(t, err)
+	require.Equal(t, "image/png", contentType)
+	require.Equal(t, "png-bytes", string(data))
+
+	_, _, err = encodeBody(ctx, passer, tests.HttpCase{BodyType: "binary", File: "missing.bin"}, source)
+	require.Error(t, err)
+}
+
+func TestHTTPExecutorMultipartBody(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.csv"), []byte("id,name\n1,alice\n"), 0o644))
+
+	var (
+		fields   map[string][]string
+		fileName string
+		content  []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fields = r.MultipartForm.Value
+
+		file, header, err := r.FormFile("report")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		fileName = header.Filename
+		content, _ = io.ReadAll(file)
+	}))
+	defer server.Close()
+
+	man := newHttpManifest(server.URL, api.HttpCase{HttpCase: tests.HttpCase{
+		Name:     "upload",
+		Method:   http.MethodPost,
+		BodyType: "multipart",
+		Body:     map[string]any{"title": "{{ title }}"},
+		Files:    map[string]string{"report": "report.csv"},
+		Assert:   []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+	}})
+	man.GetMeta().SetSource(filepath.Join(dir, "http_test.yaml"))
+
+	ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+	ctx.Set("title", "Monthly")
+	require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+
+	require.Equal(t, []string{"Monthly"}, fields["title"])
+	require.Equal(t, "report.csv", fileName)
+	require.Equal(t, "id,name\n1,alice\n", string(content))
+}
+
+func TestHTTPExecutorQuery(t *testing.T) {
+	var rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+	}))
+	defer server.Close()
+
+	man := newHttpManifest(server.URL, api.HttpCase{HttpCase: tests.HttpCase{
+		Name:     "search",
+		Method:   http.MethodGet,
+		Endpoint: "/users?active=true",
+		Query: map[string]any{
+			"q":    "{{ search }}",
+			"tag":  []any{"a&b", "c d"},
+			"page": 2,
+		},
+	}})
+
+	ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+	ctx.Set("search", "john doe/ä")
+	require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+	require.Equal(t, "active=true&page=2&q=john+doe%2F%C3%A4&tag=a%26b&tag=c+d", rawQuery)
+}
+
+func TestWithQuery(t *testing.T) {
+	rawURL, err := withQuery("https://bucket.local/file?X-Amz-Signature=ab%2Fc&flag&b=2&a=1", map[string]any{"page": 1, "tag": []any{"x y", "z"}})
+	require.NoError(t, err)
+	require.Equal(t, "https://bucket.local/file?X-Amz-Signature=ab%2Fc&flag&b=2&a=1&page=1&tag=x+y&tag=z", rawURL)
+
+	rawURL, err = withQuery("https://bucket.local/file?flag", nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://bucket.local/file?flag", rawURL)
+}
+
+func TestApplyAuth(t *testing.T) {
+	t.Setenv("QUBE_TEST_TOKEN", "env-token")
+
+	ctx := runctx.NewCtxBuilder().Build()
+	passer := form.NewRunner()
+	tokens := newTokenCache()
+
+	_, headers, _, err := applyAuth(ctx, passer, tokens, &kinds.Auth{Basic: &kinds.BasicAuth{Username: "alice", Password: "secret"}}, transportConfig{}, "http://api.local", nil)
+	require.NoError(t, err)
+	require.Equal(t, "Basic YWxpY2U6c2VjcmV0", headers["Authorization"])
+
+	_, headers, _, err = applyAuth(ctx, passer, tokens, &kinds.Auth{Bearer: &kinds.BearerAuth{Token: "{{ Env(QUBE_TEST_TOKEN) }}"}}, transportConfig{}, "http://api.local", nil)
+	require.NoError(t, err)
+	require.Equal(t, "Bearer env-token", headers["Authorization"])
+
+	_, headers, _, err = applyAuth(ctx, passer, tokens, &kinds.Auth{Bearer: &kinds.BearerAuth{Token: "ignored"}}, transportConfig{}, "http://api.local", map[string]string{"authorization": "Bearer case"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"authorization": "Bearer case"}, headers)
+
+	_, headers, _, err = applyAuth(ctx, passer, tokens, &kinds.Auth{APIKey: &kinds.APIKeyAuth{Name: "X-Api-Key", Value: "k1"}}, transportConfig{}, "http://api.local", nil)
+	require.NoError(t, err)
+	require.Equal(t, "k1", headers["X-Api-Key"])
+
+	rawURL, headers, _, err := applyAuth(ctx, passer, tokens, &kinds.Auth{APIKey: &kinds.APIKeyAuth{Name: "api_key", Value: "k 2", In: "query"}}, transportConfig{}, "http://api.local/users?page=1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "http://api.local/users?page=1&api_key=k+2", rawURL)
+	require.Empty(t, headers)
+
+	_, _, _, err = applyAuth(ctx, passer, tokens, &kinds.Auth{Bearer: &kinds.BearerAuth{Token: "{{ Env(QUBE_TEST_UNSET_TOKEN) }}"}}, transportConfig{}, "http://api.local", nil)
+	require.ErrorContains(t, err, "bearer token has unresolved template {{ Env(QUBE_TEST_UNSET_TOKEN) }}")
+
+	_, _, _, err = applyAuth(ctx, passer, tokens, &kinds.Auth{OAuth2: &kinds.OAuth2Auth{TokenURL: "http://auth.local", ClientID: "qube", ClientSecret: "{{ Env(QUBE_TEST_UNSET_SECRET) }}"}}, transportConfig{}, "http://api.local", nil)
+	require.ErrorContains(t, err, "oauth2 clientSecret has unresolved template")
+}
+
+func TestTokenCache(t *testing.T) {
+	var (
+		issued    atomic.Int64
+		refreshed atomic.Int64
+		expiresIn atomic.Int64
+	)
+	expiresIn.Store(3600)
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("client_id") != "qube" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.PostForm.Get("grant_type") {
+		case "client_credentials":
+			if r.PostForm.Get("client_secret") != "secret" || r.PostForm.Get("scope") != "read write" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		case "password":
+			if r.PostForm.Get("username") != "alice" || r.PostForm.Get("password") != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh-1" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			refreshed.Add(1)
+		}
+
+		n := issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d,"refresh_token":"refresh-1"}`, n, expiresIn.Load())
+	}))
+	defer tokenServer.Close()
+
+	auth := kinds.OAuth2Auth{TokenURL: tokenServer.URL, Grant: "client_credentials", ClientID: "qube", ClientSecret: "secret", Scopes: []string{"read", "write"}}
+
+	cache := newTokenCache()
+	token, err := cache.Token(t.Context(), auth, transportConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "token-1", token)
+
+	token, err = cache.Token(t.Context(), auth, transportConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "token-1", token, "valid token is served from cache")
+
+	expiresIn.Store(1)
+	cache = newTokenCache()
+	token, err = cache.Token(t.Context(), auth, transportConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "token-2", token)
+
+	token, err = cache.Token(t.Context(), auth, transportConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "token-2", token, "short lived token is served from cache")
+
+	cache.now = func() time.Time { return time.Now().Add(time.Second) }
+	token, err = cache.Token(t.Context(), auth, transportConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "token-3", token, "expired token is refreshed")
+	require.EqualValues(t, 1, refreshed.Load())
+
+	token, err = cache.Token(t.Context(), kinds.OAuth2Auth{TokenURL: tokenServer.URL, Grant: "password", ClientID: "qube", Username: "alice", Password: "pass"}, transportConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "token-4", token)
+
+	_, err = cache.Token(t.Context(), kinds.OAuth2Auth{TokenURL: tokenServer.URL, Grant: "password", ClientID: "qube", Username: "alice", Password: "wrong"}, transportConfig{})
+	require.ErrorContains(t, err, "token endpoint responded 401")
+}
+
+func TestTokenCacheTransport(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":3600}`, issued.Add(1))
+	}))
+	defer tokenServer.Close()
+
+	auth := kinds.OAuth2Auth{TokenURL: tokenServer.URL, Grant: "client_credentials", ClientID: "qube"}
+	cache := newTokenCache()
+
+	_, err := cache.Token(t.Context(), auth, transportConfig{})
+	require.ErrorContains(t, err, "certificate", "token endpoint is reached with the target transport")
+
+	transport := transportConfig{tls: &kinds.TLS{InsecureSkipVerify: true}}
+	tokens := make(chan string, 5)
+	for range cap(tokens) {
+		go func() {
+			token, err := cache.Token(t.Context(), auth, transport)
+			if err != nil {
+				token = err.Error()
+			}
+			tokens <- token
+		}()
+	}
+
+	for range cap(tokens) {
+		require.Equal(t, "token-1", <-tokens)
+	}
+	require.EqualValues(t, 1, issued.Load(), "concurrent cases share a single token request")
+}
+
+func TestHTTPExecutorTokenRefresh(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":60}`, issued.Add(1))
+	}))
+	defer tokenServer.Close()
+
+	var (
+		elapsed atomic.Int64
+		sent    []string
+	)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get("Authorization"))
+		if len(sent) == 1 {
+			// Token expires while the case waits for the next attempt
+			elapsed.Store(int64(time.Hour))
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer backend.Close()
+
+	man := newHttpManifest(backend.URL, api.HttpCase{HttpCase: tests.HttpCase{
+		Name:   "retried",
+		Method: http.MethodGet,
+		Assert: []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+		Retry:  &tests.Retry{Attempts: 2, Delay: time.Millisecond, OnStatus: []int{http.StatusServiceUnavailable}},
+	}})
+	man.Spec.Auth = &kinds.Auth{OAuth2: &kinds.OAuth2Auth{TokenURL: tokenServer.URL, ClientID: "qube"}}
+
+	executor := NewHTTPExecutor()
+	executor.tokens.now = func() time.Time { return time.Now().Add(time.Duration(elapsed.Load())) }
+
+	ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+	require.NoError(t, executor.Run(ctx, man))
+	require.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, sent)
+}
+
+func TestHTTPExecutorServerAuth(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"oauth-token","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer oauth-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer backend.Close()
+
+	server := newServerManifest("api", manifests.DefaultNamespace, backend.URL, nil)
+	server.Spec.Auth = &kinds.Auth{OAuth2: &kinds.OAuth2Auth{TokenURL: tokenServer.URL, ClientID: "qube"}}
+
+	man := newHttpManifest("api", api.HttpCase{HttpCase: tests.HttpCase{
+		Name:   "authorized",
+		Method: http.MethodGet,
+		Assert: []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+	}})
+
+	ctx := runctx.NewCtxBuilder().WithManifests(server, man).Build()
+	require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+}
+
+func TestRequestSigners(t *testing.T) {
+	ctx := runctx.NewCtxBuilder().Build()
+	passer := form.NewRunner()
+	now := func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) }
+
+	t.Run("sigv4", func(t *testing.T) {
+		created, err := newRequestSigner(ctx, passer, &kinds.Signing{SigV4: &kinds.SigV4Signing{
+			AccessKey: "AKIDEXAMPLE",
+			SecretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+			Region:    "us-east-1",
+			Service:   "service",
+		}})
+		require.NoError(t, err)
+		signer := created.(*sigV4Signer)
+		signer.now = now
+
+		req := httptest.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+		require.NoError(t, signer.Sign(req, nil))
+		require.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+		require.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31", req.Header.Get("Authorization"))
+	})
+
+	t.Run("hmac", func(t *testing.T) {
+		created, err := newRequestSigner(ctx, passer, &kinds.Signing{HMAC: &kinds.HMACSigning{
+			Secret:    "secret",
+			Headers:   []string{"Content-Type"},
+			Timestamp: "X-Timestamp",
+			Encoding:  "base64",
+		}})
+		require.NoError(t, err)
+		signer := created.(*hmacSigner)
+		signer.now = now
+
+		req := httptest.NewRequest(http.MethodPost, "http://api.local/orders?id=1", nil)
+		req.Header.Set("Content-Type", "application/json")
+		require.NoError(t, signer.Sign(req, []byte(`{"id":1}`)))
+
+		canonical := "POST\n/orders?id=1\nx-timestamp:1440938160\ncontent-type:application/json\n" + hashHex([]byte(`{"id":1}`))
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(canonical))
+
+		require.Equal(t, "1440938160", req.Header.Get("X-Timestamp"))
+		require.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), req.Header.Get("X-Signature"))
+	})
+
+	t.Run("canonical query keeps unreserved characters", func(t *testing.T) {
+		query := url.Values{"b": {"x y", "a+b"}, "a~1": {"-._~*/ä"}}
+		require.Equal(t, "a~1=-._~%2A%2F%C3%A4&b=a%2Bb&b=x%20y", canonicalQuery(query))
+	})
+
+	t.Run("canonical uri encodes path segments", func(t *testing.T) {
+		u, err := url.Parse("https://example.amazonaws.com/documents%20and%20settings/a:b@c/~x")
+		require.NoError(t, err)
+		require.Equal(t, "/documents%2520and%2520settings/a%3Ab%40c/~x", canonicalURI(u, true))
+		require.Equal(t, "/documents%20and%20settings/a%3Ab%40c/~x", canonicalURI(u, false))
+		require.Equal(t, "/", canonicalURI(&url.URL{}, true))
+
+		s3, err := newRequestSigner(ctx, passer, &kinds.Signing{SigV4: &kinds.SigV4Signing{AccessKey: "a", SecretKey: "s", Region: "us-east-1", Service: "s3"}})
+		require.NoError(t, err)
+		require.False(t, s3.(*sigV4Signer).doubleEncode)
+	})
+
+	t.Run("unresolved secrets", func(t *testing.T) {
+		_, err := newRequestSigner(ctx, passer, &kinds.Signing{HMAC: &kinds.HMACSigning{Secret: "{{ Env(QUBE_TEST_UNSET_SIGNING_SECRET) }}"}})
+		require.ErrorContains(t, err, "signing hmac secret has unresolved template")
+
+		_, err = newRequestSigner(ctx, passer, &kinds.Signing{SigV4: &kinds.SigV4Signing{AccessKey: "a", SecretKey: "{{ Env(QUBE_TEST_UNSET_SIGNING_SECRET) }}", Region: "us-east-1", Service: "sqs"}})
+		require.ErrorContains(t, err, "signing sigv4 secretKey has unresolved template")
+
+		_, err = newSigners().New(ctx, passer, &kinds.Signing{Custom: &kinds.CustomSigning{Name: "static"}})
+		require.ErrorContains(t, err, "signer static is not registered")
+
+		registry := newSigners()
+		registry.Register("static", func(map[string]string) (RequestSigner, error) { return &hmacSigner{}, nil })
+		_, err = registry.New(ctx, passer, &kinds.Signing{Custom: &kinds.CustomSigning{Name: "static", Params: map[string]string{"key": "{{ Env(QUBE_TEST_UNSET_SIGNING_SECRET) }}"}}})
+		require.ErrorContains(t, err, "signing static param key has unresolved template")
+	})
+}
+
+func TestHTTPExecutorSigning(t *testing.T) {
+	t.Setenv("QUBE_TEST_SIGNING_SECRET", "secret")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		canonical := r.Method + "\n" + r.URL.RequestURI() + "\n" + hashHex(body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(canonical))
+
+		if r.Header.Get("X-Signature") != hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	man := newHttpManifest(server.URL, api.HttpCase{HttpCase: tests.HttpCase{
+		Name:     "signed",
+		Method:   http.MethodPost,
+		Endpoint: "/orders",
+		Query:    map[string]any{"id": 1},
+		Body:     map[string]any{"item": "book"},
+		Assert:   []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+	}})
+	man.Spec.Signing = &kinds.Signing{HMAC: &kinds.HMACSigning{Secret: "{{ Env(QUBE_TEST_SIGNING_SECRET) }}"}}
+
+	ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+	require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+
+	t.Run("custom signer", func(t *testing.T) {
+		man.Spec.Signing = &kinds.Signing{Custom: &kinds.CustomSigning{Name: "static", Params: map[string]string{"key": "{{ Env(QUBE_TEST_SIGNING_SECRET) }}"}}}
+		require.ErrorContains(t, NewHTTPExecutor().Run(ctx, man), "signer static is not registered")
+
+		executor := NewHTTPExecutor()
+		executor.RegisterSigner("static", func(params map[string]string) (RequestSigner, error) {
+			return &hmacSigner{secret: []byte(params["key"]), header: hmacDefaultHeader, now: time.Now}, nil
+		})
+		require.NoError(t, executor.Run(ctx, man))
+	})
+}
+
+func TestHTTPExecutorTLS(t *testing.T) {
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/mtls" && len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	backend.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	backend.StartTLS()
+	defer backend.Close()
+
+	// Certificate of the test server is reused as client certificate, server only checks it is presented
+	cert := backend.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "client.key"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0o600))
+
+	newCase := func(endpoint string) api.HttpCase {
+		return api.HttpCase{HttpCase: tests.HttpCase{
+			Name:     "secure",
+			Method:   http.MethodGet,
+			Endpoint: endpoint,
+			Assert:   []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+		}}
+	}
+
+	t.Run("untrusted", func(t *testing.T) {
+		man := newHttpManifest(backend.URL, newCase("/"))
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.Error(t, NewHTTPExecutor().Run(ctx, man))
+	})
+
+	t.Run("ca", func(t *testing.T) {
+		man := newHttpManifest(backend.URL, newCase("/"))
+		man.Spec.TLS = &kinds.TLS{CA: "ca.pem", MinVersion: "1.2"}
+		man.GetMeta().SetSource(filepath.Join(dir, "http.yaml"))
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		man := newHttpManifest(backend.URL, newCase("/"))
+		man.Spec.TLS = &kinds.TLS{InsecureSkipVerify: true}
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+	})
+
+	t.Run("server mtls", func(t *testing.T) {
+		server := newServerManifest("secure-api", manifests.DefaultNamespace, backend.URL, nil)
+		server.Spec.TLS = &kinds.TLS{CA: filepath.Join(dir, "ca.pem"), Cert: filepath.Join(dir, "ca.pem"), Key: filepath.Join(dir, "client.key")}
+
+		man := newHttpManifest("secure-api", newCase("/mtls"))
+		ctx := runctx.NewCtxBuilder().WithManifests(server, man).Build()
+		require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+	})
+
+	t.Run("missing ca", func(t *testing.T) {
+		_, err := newTransports().Client(transportConfig{tls: &kinds.TLS{CA: "missing.pem"}, source: filepath.Join(dir, "http.yaml")})
+		require.ErrorContains(t, err, "read ca bundle failed")
+	})
+}
+
+func TestHTTPExecutorTransport(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusFound)
+		case "/chain":
+			http.Redirect(w, r, "/old", http.StatusFound)
+		}
+	}))
+	defer backend.Close()
+
+	var proxied atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		proxied.Store(r.URL.String())
+	}))
+	defer proxy.Close()
+
+	disabled := false
+
+	t.Run("redirects disabled", func(t *testing.T) {
+		man := newHttpManifest(backend.URL, api.HttpCase{HttpCase: tests.HttpCase{
+			Name:     "redirect",
+			Method:   http.MethodGet,
+			Endpoint: "/old",
+			Assert: []*tests.Assert{
+				{Target: "status", Equals: http.StatusFound},
+				{Target: "headers", Header: "Location", Equals: "/new"},
+			},
+		}})
+		man.Spec.Transport = &kinds.Transport{FollowRedirects: &disabled}
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+	})
+
+	t.Run("max redirects", func(t *testing.T) {
+		man := newHttpManifest(backend.URL, api.HttpCase{HttpCase: tests.HttpCase{
+			Name:     "redirect chain",
+			Method:   http.MethodGet,
+			Endpoint: "/chain",
+		}})
+		man.Spec.Transport = &kinds.Transport{MaxRedirects: 1}
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.ErrorContains(t, NewHTTPExecutor().Run(ctx, man), "stopped after 1 redirects")
+	})
+
+	t.Run("server proxy", func(t *testing.T) {
+		server := newServerManifest("proxied", manifests.DefaultNamespace, "http://api.invalid", nil)
+		server.Spec.Transport = &kinds.Transport{Proxy: proxy.URL}
+
+		man := newHttpManifest("proxied", api.HttpCase{HttpCase: tests.HttpCase{
+			Name:     "through proxy",
+			Method:   http.MethodGet,
+			Endpoint: "/ping",
+			Assert:   []*tests.Assert{{Target: "status", Equals: http.StatusOK}},
+		}})
+
+		ctx := runctx.NewCtxBuilder().WithManifests(server, man).Build()
+		require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+		require.Equal(t, "http://api.invalid/ping", proxied.Load())
+	})
+
+	t.Run("settings", func(t *testing.T) {
+		transport, err := buildTransport(transportConfig{settings: &kinds.Transport{
+			HTTP2:           &disabled,
+			KeepAlive:       &disabled,
+			MaxIdleConns:    5,
+			MaxConnsPerHost: 2,
+			IdleConnTimeout: time.Second,
+		}})
+		require.NoError(t, err)
+		require.False(t, transport.ForceAttemptHTTP2)
+		require.NotNil(t, transport.TLSNextProto)
+		require.True(t, transport.DisableKeepAlives)
+		require.Equal(t, 5, transport.MaxIdleConns)
+		require.Equal(t, 2, transport.MaxConnsPerHost)
+		require.Equal(t, time.Second, transport.IdleConnTimeout)
+
+		_, err = buildTransport(transportConfig{settings: &kinds.Transport{Proxy: "not a url"}})
+		require.ErrorContains(t, err, "invalid proxy url")
+	})
+}
+
+func TestTransportsClient(t *testing.T) {
+	shared := newTransports()
+	disabled := false
+
+	client, err := shared.Client(transportConfig{})
+	require.NoError(t, err)
+	require.Zero(t, client.Timeout)
+
+	same, err := shared.Client(transportConfig{source: "other/http.yaml"})
+	require.NoError(t, err)
+	require.Same(t, client, same)
+
+	first, err := shared.Client(transportConfig{settings: &kinds.Transport{FollowRedirects: &disabled}})
+	require.NoError(t, err)
+	second, err := shared.Client(transportConfig{settings: &kinds.Transport{FollowRedirects: &disabled}})
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	require.NotSame(t, client, first)
+}
+
+func TestHTTPExecutorRequestContext(t *testing.T) {
+	var conns atomic.Int32
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second * 5):
+			}
+		}
+	}))
+	backend.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	backend.Start()
+	defer backend.Close()
+
+	newCase := func(name, endpoint string, timeout time.Duration) api.HttpCase {
+		return api.HttpCase{HttpCase: tests.HttpCase{Name: name, Method: http.MethodGet, Endpoint: endpoint, Timeout: timeout}}
+	}
+
+	t.Run("connections reused between manifests", func(t *testing.T) {
+		executor := NewHTTPExecutor()
+		for range 3 {
+			man := newHttpManifest(backend.URL, newCase("fast", "/", 0), newCase("fast with timeout", "/", time.Second))
+			ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+			require.NoError(t, executor.Run(ctx, man))
+		}
+		require.EqualValues(t, 1, conns.Load())
+	})
+
+	t.Run("case timeout", func(t *testing.T) {
+		man := newHttpManifest(backend.URL, newCase("slow", "/slow", time.Millisecond*50))
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.ErrorContains(t, NewHTTPExecutor().Run(ctx, man), "timed out")
+	})
+
+	t.Run("run cancel aborts request", func(t *testing.T) {
+		runCtx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(time.Millisecond*50, cancel)
+
+		man := newHttpManifest(backend.URL, newCase("slow", "/slow", 0))
+		ctx := runctx.NewCtxBuilder().WithContext(runCtx).WithManifests(man).Build()
+
+		start := time.Now()
+		require.ErrorContains(t, NewHTTPExecutor().Run(ctx, man), "canceled")
+		require.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestHTTPExecutorRetrySnapshot(t *testing.T) {
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"state":"starting"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"state":"ready"}`))
+	}))
+	defer server.Close()
+
+	man := newHttpManifest(server.URL, api.HttpCase{HttpCase: tests.HttpCase{
+		Name:   "eventually ready",
+		Method: http.MethodGet,
+		Assert: []*tests.Assert{
+			{Target: "snapshot"},
+			{Target: "status", Equals: http.StatusOK},
+		},
+		Retry: &tests.Retry{Attempts: 5, Delay: time.Millisecond, UntilAssert: true},
+	}})
+	man.GetMeta().SetSource(filepath.Join(t.TempDir(), "http.yaml"))
+
+	ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+	require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+	require.EqualValues(t, 3, hits.Load())
+
+	snapshot, err := os.ReadFile(assert.SnapshotPath(man.GetMeta().GetSource(), man.GetNamespace(), man.GetName(), "eventually ready"))
+	require.NoError(t, err)
+	require.Contains(t, string(snapshot), `"ready"`)
+}
+
+func TestHTTPExecutorAuthScope(t *testing.T) {
+	var targetAuth, otherAuth atomic.Value
+	target := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		targetAuth.Store(r.Header.Get("Authorization"))
+	}))
+	defer target.Close()
+
+	other := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		otherAuth.Store(r.Header.Get("Authorization"))
+	}))
+	defer other.Close()
+
+	man := newHttpManifest(target.URL,
+		api.HttpCase{HttpCase: tests.HttpCase{Name: "target relative", Method: http.MethodGet, Endpoint: "/me"}},
+		api.HttpCase{HttpCase: tests.HttpCase{Name: "target absolute", Method: http.MethodGet, Url: target.URL + "/me"}},
+		api.HttpCase{HttpCase: tests.HttpCase{Name: "third party", Method: http.MethodGet, Url: other.URL + "/hook"}},
+	)
+	man.Spec.Auth = &kinds.Auth{Bearer: &kinds.BearerAuth{Token: "secret"}}
+
+	ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+	require.NoError(t, NewHTTPExecutor().Run(ctx, man))
+	require.Equal(t, "Bearer secret", targetAuth.Load())
+	require.Equal(t, "", otherAuth.Load())
+}
@@ -0,0 +1,468 @@
+package executors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests/load"
+	"github.com/apiqube/cli/internal/core/runner/assert"
+	"github.com/apiqube/cli/internal/core/runner/form"
+	"github.com/apiqube/cli/internal/core/runner/hooks"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+	"github.com/apiqube/cli/internal/core/runner/metrics"
+	"github.com/apiqube/cli/internal/core/runner/save"
+)
+
+const (
+	httpLoadExecutorOutputPrefix = "HTTP Load Executor:"
+	loadAgentIdlePoll            = time.Millisecond * 50
+)
+
+var _ interfaces.Executor = (*HTTPLoadExecutor)(nil)
+
+type HTTPLoadExecutor struct {
+	extractor  *save.Extractor
+	assertor   *assert.Runner
+	passer     *form.Runner
+	hooks      hooks.Runner // Used when the plan runner has not shared its own, see hooks.FromContext
+	tokens     *tokenCache
+	transports *transports
+}
+
+func NewHTTPLoadExecutor() *HTTPLoadExecutor {
+	return &HTTPLoadExecutor{
+		extractor:  save.NewExtractor(),
+		assertor:   assert.NewRunner(),
+		passer:     form.NewRunner(),
+		hooks:      hooks.NewDefaultHooksRunner(),
+		tokens:     newTokenCache(),
+		transports: sharedTransports,
+	}
+}
+
+func (e *HTTPLoadExecutor) Run(ctx interfaces.ExecutionContext, manifest manifests.Manifest) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("%s run cancelled, run context was canceled", httpLoadExecutorOutputPrefix)
+	default:
+	}
+
+	loadMan, ok := manifest.(*load.Http)
+	if !ok {
+		return fmt.Errorf("%s manifest %s is not a %s kind", httpLoadExecutorOutputPrefix, manifest.GetID(), manifests.HttpLoadTestKind)
+	}
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(loadMan.Spec.Cases))
+
+	for _, c := range loadMan.Spec.Cases {
+		testCase := c
+		if testCase.Parallel {
+			wg.Add(1)
+			go func(tc load.HttpCase) {
+				defer wg.Done()
+				if err := e.runCase(ctx, loadMan, tc); err != nil {
+					errCh <- err
+				}
+			}(testCase)
+		} else {
+			if err := e.runCase(ctx, loadMan, testCase); err != nil {
+				return err
+			}
+		}
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	var rErr error
+	for er := range errCh {
+		rErr = errors.Join(rErr, er)
+	}
+
+	return rErr
+}
+
+// loadRequest is a request template resolved once per load case and shared between agents
+type loadRequest struct {
+	method      string
+	url         string
+	headers     map[string]string
+	token       *tokenSource
+	body        []byte
+	contentType string
+	client      *http.Client
+	timeout     time.Duration
+	source      string
+}
+
+// loadSample holds the outcome of a single request sent by an agent
+type loadSample struct {
+	req      *http.Request
+	resp     *http.Response
+	reqBody  []byte
+	respBody []byte
+	duration time.Duration
+	err      error
+}
+
+// runCase runs case hooks once around the whole load case, not around every request of agents
+func (e *HTTPLoadExecutor) runCase(ctx interfaces.ExecutionContext, man *load.Http, c load.HttpCase) (rErr error) {
+	output := ctx.GetOutput()
+
+	caseResult := &interfaces.CaseResult{
+		Name:    c.Name,
+		Values:  make(map[string]any),
+		Details: make(map[string]any),
+	}
+
+	stats := newLoadStats(c.SaveEntry)
+
+	scope := hooks.Scope{ManifestID: man.GetID(), Case: c.Name}
+
+	output.StartCase(man, c.Name)
+	defer func() {
+		if rErr != nil {
+			scope.Err = rErr
+			if err := runCaseHooks(ctx, e.hooks, hooks.OnFailure, scope, man.Spec.Hooks, c.Hooks); err != nil {
+				caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("on failure hooks failed: %s", err.Error()))
+				rErr = errors.Join(rErr, err)
+			}
+		}
+
+		e.saveSamples(ctx, man, c, stats)
+
+		last := stats.last
+		if last == nil {
+			last = &loadSample{}
+		}
+
+		metrics.CollectHTTPMetrics(last.req, last.resp, c.Details, caseResult)
+		e.extractor.Extract(ctx, man, c.HttpCase, last.resp, last.reqBody, last.respBody, caseResult)
+
+		output.EndCase(man, c.Name, caseResult)
+	}()
+
+	duration := c.Duration
+	if duration == 0 && c.Type != "" {
+		duration = loadDefaultDuration
+	}
+
+	profile, err := newLoadProfile(c, duration)
+	if err != nil {
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("invalid load profile: %s", err.Error()))
+		return fmt.Errorf("load case %s failed: %w", c.Name, err)
+	}
+
+	if err = runCaseHooks(ctx, e.hooks, hooks.BeforeRequest, scope, man.Spec.Hooks, c.Hooks); err != nil {
+		if skipCase(err, caseResult) {
+			output.Logf(interfaces.WarnLevel, "%s HTTP Load Test %s skipped by before request hooks", httpLoadExecutorOutputPrefix, c.Name)
+			return nil
+		}
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("before request hooks failed: %s", err.Error()))
+		return fmt.Errorf("load case %s before request hooks failed: %w", c.Name, err)
+	}
+
+	req, err := e.prepareRequest(ctx, man, c)
+	if err != nil {
+		caseResult.Errors = append(caseResult.Errors, err.Error())
+		return fmt.Errorf("load case %s failed: %w", c.Name, err)
+	}
+
+	output.Logf(interfaces.InfoLevel, "%s %s case started with up to %d agents", httpLoadExecutorOutputPrefix, c.Name, profile.MaxAgents())
+
+	start := time.Now()
+	e.schedule(ctx, c, req, profile, duration, stats)
+	caseResult.Duration = time.Since(start)
+
+	stats.fill(caseResult, profile)
+
+	if err = runCaseHooks(ctx, e.hooks, hooks.AfterResponse, scope, man.Spec.Hooks, c.Hooks); err != nil {
+		caseResult.Errors = append(caseResult.Errors, fmt.Sprintf("after response hooks failed: %s", err.Error()))
+		return fmt.Errorf("load case %s after response hooks failed: %w", c.Name, err)
+	}
+
+	if err = ctx.Err(); err != nil {
+		caseResult.Errors = append(caseResult.Errors, "load case was canceled")
+		return fmt.Errorf("load case %s canceled: %w", c.Name, err)
+	}
+
+	if stats.total == 0 {
+		caseResult.Errors = append(caseResult.Errors, "no requests were sent")
+		return fmt.Errorf("load case %s failed: no requests were sent", c.Name)
+	}
+
+	// Thresholds replace the default "no failed requests" criteria when they are set
+	if len(c.Thresholds) > 0 {
+		if err = metrics.EvaluateThresholds(c.Thresholds, caseResult.Metrics); err != nil {
+			caseResult.Errors = append(caseResult.Errors, strings.Split(err.Error(), "\n")...)
+			return fmt.Errorf("load case %s thresholds failed: %w", c.Name, err)
+		}
+	} else if stats.failed > 0 {
+		return fmt.Errorf("load case %s failed: %d of %d requests failed", c.Name, stats.failed, stats.total)
+	}
+
+	caseResult.Success = true
+	output.Logf(interfaces.InfoLevel, "%s HTTP Load Test %s passed", httpLoadExecutorOutputPrefix, c.Name)
+	return nil
+}
+
+// prepareRequest resolves templates of the case once, so every agent sends the same request
+func (e *HTTPLoadExecutor) prepareRequest(ctx interfaces.ExecutionContext, man *load.Http, c load.HttpCase) (*loadRequest, error) {
+	caseReq, err := resolveCaseRequest(ctx, e.passer, e.tokens, man.GetNamespace(), man.Spec.Target, caseOptions{}, c.HttpCase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve target: %s", err.Error())
+	}
+
+	req := &loadRequest{
+		method:  c.Method,
+		url:     caseReq.url,
+		headers: caseReq.headers,
+		token:   caseReq.token,
+		timeout: httpExecutorRunTimeout,
+		source:  man.GetMeta().GetSource(),
+	}
+	if c.Timeout > 0 {
+		req.timeout = c.Timeout
+	}
+
+	if req.client, err = e.transports.Client(caseReq.transport); err != nil {
+		return nil, fmt.Errorf("failed to configure transport: %s", err.Error())
+	}
+
+	if req.body, req.contentType, err = encodeBody(ctx, e.passer, c.HttpCase, man.GetMeta().GetSource()); err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %s", err.Error())
+	}
+
+	return req, nil
+}
+
+// schedule starts agents according to the profile and waits until duration is over or every agent made its repeats
+func (e *HTTPLoadExecutor) schedule(ctx interfaces.ExecutionContext, c load.HttpCase, req *loadRequest, profile loadProfile, duration time.Duration, stats *loadStats) {
+	runCtx, cancel := context.WithCancel(ctx)
+	if duration > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, duration)
+	}
+	defer cancel()
+
+	var limiter <-chan time.Time
+	if c.RPS > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(c.RPS))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	repeats := 0
+	if duration == 0 {
+		repeats = positiveOr(c.Repeats, 1)
+	}
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for agent := 0; agent < profile.MaxAgents(); agent++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+
+			for done := 0; repeats == 0 || done < repeats; {
+				if runCtx.Err() != nil {
+					return
+				}
+
+				if id >= profile.AgentsAt(time.Since(start)) {
+					select {
+					case <-runCtx.Done():
+						return
+					case <-time.After(loadAgentIdlePoll):
+					}
+					continue
+				}
+
+				if limiter != nil {
+					select {
+					case <-runCtx.Done():
+						return
+					case <-limiter:
+					}
+				}
+
+				sample := e.doRequest(runCtx, ctx, c, req)
+				if sample.err != nil && runCtx.Err() != nil {
+					// Request was interrupted by the end of the case, it is not a failure
+					return
+				}
+
+				stats.add(sample)
+				done++
+			}
+		}(agent)
+	}
+
+	wg.Wait()
+}
+
+func (e *HTTPLoadExecutor) doRequest(runCtx context.Context, ctx interfaces.ExecutionContext, c load.HttpCase, lr *loadRequest) *loadSample {
+	sample := &loadSample{reqBody: lr.body}
+
+	reqCtx, cancel := context.WithTimeout(runCtx, lr.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, lr.method, lr.url, bytes.NewReader(lr.body))
+	if err != nil {
+		sample.err = fmt.Errorf("create request failed: %w", err)
+		return sample
+	}
+
+	if lr.contentType != "" {
+		req.Header.Set("Content-Type", lr.contentType)
+	}
+	for k, v := range lr.headers {
+		req.Header.Set(k, v)
+	}
+
+	// Cached token is reused by agents until it expires, so long runs keep sending a valid token
+	if err = lr.token.Authorize(req); err != nil {
+		sample.err = err
+		return sample
+	}
+
+	sample.req = req
+
+	start := time.Now()
+	resp, err := lr.client.Do(req)
+	if err != nil {
+		sample.duration = time.Since(start)
+		sample.err = fmt.Errorf("http request failed: %w", err)
+		return sample
+	}
+
+	respBody := &bytes.Buffer{}
+	_, err = respBody.ReadFrom(resp.Body)
+	sample.duration = time.Since(start)
+	_ = resp.Body.Close()
+
+	sample.resp = resp
+	sample.respBody = respBody.Bytes()
+
+	if err != nil {
+		sample.err = fmt.Errorf("read response body failed: %w", err)
+		return sample
+	}
+
+	if c.Assert != nil {
+		if err = e.assertor.Assert(ctx, c.Assert, resp, sample.respBody, sample.duration, assert.Files{Source: lr.source}); err != nil {
+			sample.err = fmt.Errorf("assertion failed: %w", err)
+		}
+	}
+
+	return sample
+}
+
+// saveSamples passes stored per-request samples into the save flow as separate results
+func (e *HTTPLoadExecutor) saveSamples(ctx interfaces.ExecutionContext, man *load.Http, c load.HttpCase, stats *loadStats) {
+	for i, s := range stats.samples {
+		result := &interfaces.CaseResult{
+			Name:     fmt.Sprintf("%s #%d", c.Name, i+1),
+			Success:  s.err == nil,
+			Duration: s.duration,
+			Values:   make(map[string]any),
+			Details:  make(map[string]any),
+		}
+
+		if s.resp != nil {
+			result.StatusCode = s.resp.StatusCode
+		}
+
+		if s.err != nil {
+			result.Errors = append(result.Errors, s.err.Error())
+		}
+
+		e.extractor.Extract(ctx, man, c.HttpCase, s.resp, s.reqBody, s.respBody, result)
+	}
+}
+
+// loadStats aggregates samples of a load case
+type loadStats struct {
+	mu sync.Mutex
+
+	total       int
+	failed      int
+	metrics     *metrics.Store
+	statusCodes map[int]int
+	errors      map[string]int
+
+	saveLimit int
+	samples   []*loadSample
+	last      *loadSample
+}
+
+func newLoadStats(saveLimit int) *loadStats {
+	return &loadStats{
+		metrics:     metrics.NewLoadStore(),
+		statusCodes: make(map[int]int),
+		errors:      make(map[string]int),
+		saveLimit:   saveLimit,
+	}
+}
+
+func (s *loadStats) add(sample *loadSample) {
+	metrics.RecordLoadSample(s.metrics, sample.duration, sample.err != nil)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.total++
+	s.last = sample
+
+	if sample.resp != nil {
+		s.statusCodes[sample.resp.StatusCode]++
+	}
+
+	if sample.err != nil {
+		s.failed++
+		s.errors[sample.err.Error()]++
+	}
+
+	if len(s.samples) < s.saveLimit {
+		s.samples = append(s.samples, sample)
+	}
+}
+
+func (s *loadStats) fill(result *interfaces.CaseResult, profile loadProfile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result.Details["agents"] = profile.MaxAgents()
+	result.Metrics = metrics.LoadSummary(s.metrics, result.Duration)
+
+	if len(s.statusCodes) > 0 {
+		codes := make(map[string]int, len(s.statusCodes))
+		for code, count := range s.statusCodes {
+			codes[fmt.Sprint(code)] = count
+		}
+		result.Details["statusCodes"] = codes
+	}
+
+	if s.last != nil && s.last.resp != nil {
+		result.StatusCode = s.last.resp.StatusCode
+	}
+
+	messages := make([]string, 0, len(s.errors))
+	for msg := range s.errors {
+		messages = append(messages, msg)
+	}
+	sort.Strings(messages)
+
+	for _, msg := range messages {
+		result.Errors = append(result.Errors, fmt.Sprintf("%d x %s", s.errors[msg], msg))
+	}
+}
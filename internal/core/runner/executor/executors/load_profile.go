@@ -0,0 +1,155 @@
+package executors
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests/load"
+)
+
+const (
+	loadTypeRamp = "ramp"
+	loadTypeWave = "wave"
+	loadTypeStep = "step"
+
+	loadProfileTick        = time.Second
+	loadDefaultDuration    = time.Second * 10
+	loadDefaultStepPause   = time.Second
+	loadDefaultAgentsCount = 1
+)
+
+// loadProfile describes how many agents must be active at any moment of a load case
+type loadProfile interface {
+	AgentsAt(elapsed time.Duration) int
+	MaxAgents() int
+}
+
+// newLoadProfile builds scheduling profile from load case configuration
+func newLoadProfile(c load.HttpCase, duration time.Duration) (loadProfile, error) {
+	agents := c.Agents
+	if agents <= 0 {
+		agents = loadDefaultAgentsCount
+	}
+
+	switch c.Type {
+	case "":
+		return &constantProfile{agents: agents}, nil
+	case loadTypeRamp:
+		if c.Ramp == nil {
+			return nil, fmt.Errorf("ramp config is required for %s load type", loadTypeRamp)
+		}
+		return newRampProfile(c.Ramp, duration), nil
+	case loadTypeWave:
+		if c.Wave == nil {
+			return nil, fmt.Errorf("wave config is required for %s load type", loadTypeWave)
+		}
+		if c.Wave.High < c.Wave.Low {
+			return nil, fmt.Errorf("wave high value %d is less than low value %d", c.Wave.High, c.Wave.Low)
+		}
+		return &waveProfile{low: c.Wave.Low, high: c.Wave.High, delta: positiveOr(c.Wave.Delta, 1)}, nil
+	case loadTypeStep:
+		pause := loadDefaultStepPause
+		if c.Step != nil && c.Step.Pause > 0 {
+			pause = c.Step.Pause
+		}
+		return &stepProfile{agents: agents, pause: pause}, nil
+	default:
+		return nil, fmt.Errorf("unknown load type %s", c.Type)
+	}
+}
+
+// constantProfile keeps the same amount of agents during the whole case
+type constantProfile struct {
+	agents int
+}
+
+func (p *constantProfile) AgentsAt(_ time.Duration) int {
+	return p.agents
+}
+
+func (p *constantProfile) MaxAgents() int {
+	return p.agents
+}
+
+// rampProfile linearly moves agents count from start to end spreading steps over the case duration
+type rampProfile struct {
+	start, end, delta int
+	tick              time.Duration
+}
+
+func newRampProfile(cfg *load.RampConfig, duration time.Duration) *rampProfile {
+	delta := positiveOr(cfg.Delta, 1)
+
+	distance := cfg.End - cfg.Start
+	if distance < 0 {
+		distance = -distance
+	}
+
+	steps := (distance+delta-1)/delta + 1
+	tick := duration / time.Duration(steps)
+	if tick <= 0 {
+		tick = loadProfileTick
+	}
+
+	return &rampProfile{start: cfg.Start, end: cfg.End, delta: delta, tick: tick}
+}
+
+func (p *rampProfile) AgentsAt(elapsed time.Duration) int {
+	step := int(elapsed / p.tick)
+
+	if p.end >= p.start {
+		return min(p.end, p.start+step*p.delta)
+	}
+
+	return max(p.end, p.start-step*p.delta)
+}
+
+func (p *rampProfile) MaxAgents() int {
+	return max(p.start, p.end)
+}
+
+// waveProfile oscillates agents count between low and high changing it by delta every tick
+type waveProfile struct {
+	low, high, delta int
+}
+
+func (p *waveProfile) AgentsAt(elapsed time.Duration) int {
+	span := p.high - p.low
+	if span == 0 {
+		return p.low
+	}
+
+	half := (span + p.delta - 1) / p.delta
+	pos := int(elapsed/loadProfileTick) % (half * 2)
+
+	if pos <= half {
+		return min(p.high, p.low+pos*p.delta)
+	}
+
+	return max(p.low, p.high-(pos-half)*p.delta)
+}
+
+func (p *waveProfile) MaxAgents() int {
+	return p.high
+}
+
+// stepProfile adds one agent after every pause until configured agents count is reached
+type stepProfile struct {
+	agents int
+	pause  time.Duration
+}
+
+func (p *stepProfile) AgentsAt(elapsed time.Duration) int {
+	return min(p.agents, 1+int(elapsed/p.pause))
+}
+
+func (p *stepProfile) MaxAgents() int {
+	return p.agents
+}
+
+func positiveOr(val, def int) int {
+	if val > 0 {
+		return val
+	}
+	return def
+}
@@ -0,0 +1,170 @@
+package executors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests/load"
+	runctx "github.com/apiqube/cli/internal/core/runner/context"
+	"github.com/apiqube/cli/internal/core/runner/metrics"
+	"github.com/apiqube/cli/internal/core/runner/save"
+)
+
+func newLoadManifest(target string, cases ...load.HttpCase) *load.Http {
+	man := &load.Http{
+		BaseManifest: kinds.BaseManifest{
+			Version: manifests.V1,
+			Kind:    manifests.HttpLoadTestKind,
+			Metadata: kinds.Metadata{
+				Name:      "load-test",
+				Namespace: manifests.DefaultNamespace,
+			},
+		},
+	}
+	man.Spec.Target = target
+	man.Spec.Cases = cases
+	man.Default()
+	return man
+}
+
+func TestLoadProfiles(t *testing.T) {
+	t.Run("ramp profile grows to end value", func(t *testing.T) {
+		profile, err := newLoadProfile(load.HttpCase{Type: loadTypeRamp, Ramp: &load.RampConfig{Start: 1, End: 5, Delta: 2}}, time.Second*3)
+		require.NoError(t, err)
+		require.Equal(t, 5, profile.MaxAgents())
+		require.Equal(t, 1, profile.AgentsAt(0))
+		require.Equal(t, 3, profile.AgentsAt(time.Second))
+		require.Equal(t, 5, profile.AgentsAt(time.Second*2))
+		require.Equal(t, 5, profile.AgentsAt(time.Second*10))
+	})
+
+	t.Run("wave profile oscillates between bounds", func(t *testing.T) {
+		profile, err := newLoadProfile(load.HttpCase{Type: loadTypeWave, Wave: &load.WaveConfig{Low: 1, High: 3, Delta: 1}}, 0)
+		require.NoError(t, err)
+
+		var got []int
+		for i := 0; i < 6; i++ {
+			got = append(got, profile.AgentsAt(time.Duration(i)*loadProfileTick))
+		}
+		require.Equal(t, []int{1, 2, 3, 2, 1, 2}, got)
+	})
+
+	t.Run("step profile adds agent after pause", func(t *testing.T) {
+		profile, err := newLoadProfile(load.HttpCase{Type: loadTypeStep, Agents: 3, Step: &load.StepConfig{Pause: time.Second}}, 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, profile.AgentsAt(0))
+		require.Equal(t, 2, profile.AgentsAt(time.Second))
+		require.Equal(t, 3, profile.AgentsAt(time.Minute))
+	})
+
+	t.Run("missing profile config", func(t *testing.T) {
+		_, err := newLoadProfile(load.HttpCase{Type: loadTypeRamp}, time.Second)
+		require.Error(t, err)
+	})
+}
+
+func TestHTTPLoadExecutorRun(t *testing.T) {
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	t.Run("repeats per agent", func(t *testing.T) {
+		hits.Store(0)
+
+		man := newLoadManifest(server.URL, load.HttpCase{
+			HttpCase: tests.HttpCase{
+				Name:     "repeats",
+				Method:   http.MethodGet,
+				Endpoint: "/ping",
+				Assert:   []*tests.Assert{{Target: "status", Equals: 200}},
+			},
+			Agents:    4,
+			Repeats:   5,
+			SaveEntry: 2,
+		})
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+
+		require.NoError(t, NewHTTPLoadExecutor().Run(ctx, man))
+		require.EqualValues(t, 20, hits.Load())
+
+		val, ok := ctx.Get(save.FormSaveKey(man.GetID(), save.ResultKeySuffix))
+		require.True(t, ok)
+
+		results := val.([]*save.Result)
+		require.Len(t, results, 3)
+
+		aggregate := results[len(results)-1].ResultCase
+		require.True(t, aggregate.Success)
+		require.NotEmpty(t, aggregate.Metrics)
+		require.Equal(t, metrics.MetricRequests, aggregate.Metrics[0].Name)
+		require.EqualValues(t, 20, aggregate.Metrics[0].Value)
+	})
+
+	t.Run("duration with rps limit", func(t *testing.T) {
+		hits.Store(0)
+
+		man := newLoadManifest(server.URL, load.HttpCase{
+			HttpCase: tests.HttpCase{
+				Name:   "limited",
+				Method: http.MethodGet,
+			},
+			Agents:   2,
+			RPS:      20,
+			Duration: time.Millisecond * 500,
+		})
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+
+		require.NoError(t, NewHTTPLoadExecutor().Run(ctx, man))
+		require.LessOrEqual(t, hits.Load(), int64(12))
+		require.Positive(t, hits.Load())
+	})
+
+	t.Run("failed requests fail the case", func(t *testing.T) {
+		man := newLoadManifest(server.URL, load.HttpCase{
+			HttpCase: tests.HttpCase{
+				Name:   "failing",
+				Method: http.MethodGet,
+				Assert: []*tests.Assert{{Target: "status", Equals: 500}},
+			},
+			Repeats: 3,
+		})
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+
+		require.Error(t, NewHTTPLoadExecutor().Run(ctx, man))
+	})
+
+	t.Run("thresholds decide case result", func(t *testing.T) {
+		man := newLoadManifest(server.URL,
+			load.HttpCase{
+				HttpCase: tests.HttpCase{
+					Name:   "tolerated",
+					Method: http.MethodGet,
+					Assert: []*tests.Assert{{Target: "status", Equals: 500}},
+				},
+				Repeats:    2,
+				Thresholds: []string{"errorRate <= 100%"},
+			},
+		)
+
+		ctx := runctx.NewCtxBuilder().WithManifests(man).Build()
+		require.NoError(t, NewHTTPLoadExecutor().Run(ctx, man))
+
+		man.Spec.Cases[0].Thresholds = []string{"errorRate < 1%", "p99 < 10s"}
+		require.Error(t, NewHTTPLoadExecutor().Run(ctx, man))
+	})
+}
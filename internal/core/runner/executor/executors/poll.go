@@ -0,0 +1,103 @@
+package executors
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/tidwall/gjson"
+)
+
+const pollDefaultInterval = time.Second
+
+var pollConditionRe = regexp.MustCompile(`^\s*(\S+)\s+(==|!=|<=|>=|<|>)\s+(.+?)\s*$`)
+
+// pollCondition is a parsed poll until expression like `status == "done"` or `data.ready`,
+// operator must be separated from path and value by spaces, so gjson queries may be used as path
+type pollCondition struct {
+	path     string
+	operator string
+	value    any
+}
+
+func parsePollCondition(expr string) (*pollCondition, error) {
+	cond := &pollCondition{}
+
+	matches := pollConditionRe.FindStringSubmatch(expr)
+	if matches == nil {
+		cond.path = strings.TrimSpace(expr)
+	} else {
+		cond.path, cond.operator = matches[1], matches[2]
+		if err := json.Unmarshal([]byte(matches[3]), &cond.value); err != nil {
+			cond.value = matches[3]
+		}
+	}
+
+	cond.path = strings.TrimPrefix(strings.TrimPrefix(cond.path, "$"), ".")
+	if cond.path == "" {
+		return nil, fmt.Errorf("invalid poll condition %q, expected format: <path> [<operator> <value>]", expr)
+	}
+
+	if cond.operator != "" && cond.operator != "==" && cond.operator != "!=" {
+		if _, ok := cond.value.(float64); !ok {
+			return nil, fmt.Errorf("invalid poll condition %q, operator %s requires numeric value", expr, cond.operator)
+		}
+	}
+
+	return cond, nil
+}
+
+// Match reports whether the condition holds for the JSON body,
+// condition without operator holds when path exists and is not false or null
+func (c *pollCondition) Match(body []byte) bool {
+	result := gjson.GetBytes(body, c.path)
+	if !result.Exists() {
+		return false
+	}
+
+	switch c.operator {
+	case "":
+		return result.Type != gjson.Null && result.Type != gjson.False
+	case "==":
+		return c.equals(result)
+	case "!=":
+		return !c.equals(result)
+	}
+
+	actual, err := strconv.ParseFloat(result.String(), 64)
+	if err != nil {
+		return false
+	}
+
+	expected := c.value.(float64)
+	switch c.operator {
+	case "<":
+		return actual < expected
+	case "<=":
+		return actual <= expected
+	case ">":
+		return actual > expected
+	case ">=":
+		return actual >= expected
+	default:
+		return false
+	}
+}
+
+func (c *pollCondition) equals(result gjson.Result) bool {
+	switch expected := c.value.(type) {
+	case nil:
+		return result.Type == gjson.Null
+	case bool:
+		return (result.Type == gjson.True || result.Type == gjson.False) && result.Bool() == expected
+	case float64:
+		return result.Type == gjson.Number && result.Float() == expected
+	case string:
+		return result.String() == expected
+	default:
+		return result.Raw == fmt.Sprint(expected)
+	}
+}
@@ -0,0 +1,107 @@
+package executors
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+)
+
+const (
+	retryBackoffFixed       = "fixed"
+	retryBackoffExponential = "exponential"
+	retryDefaultDelay       = time.Millisecond * 500
+	retryDefaultMaxDelay    = time.Minute
+)
+
+// httpAttempt is a single request attempt recorded into case result details
+type httpAttempt struct {
+	Attempt    int           `json:"attempt"`
+	StatusCode int           `json:"statusCode,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	Error      string        `json:"error,omitempty"`
+}
+
+func (a httpAttempt) String() string {
+	result := fmt.Sprintf("status %d", a.StatusCode)
+	if a.Error != "" {
+		result = a.Error
+	}
+	return fmt.Sprintf("#%d %s in %s", a.Attempt, result, a.Duration.Round(time.Millisecond))
+}
+
+// retryPolicy decides whether a failed attempt should be repeated and how long to wait before it
+type retryPolicy struct {
+	retry *tests.Retry
+}
+
+func newRetryPolicy(retry *tests.Retry) retryPolicy {
+	return retryPolicy{retry: retry}
+}
+
+// Attempts returns the maximum number of attempts, one when retry is not configured
+func (p retryPolicy) Attempts() int {
+	if p.retry == nil {
+		return 1
+	}
+	return positiveOr(p.retry.Attempts, 1)
+}
+
+// ShouldRetry reports whether the attempt outcome matches any retry condition
+func (p retryPolicy) ShouldRetry(statusCode int, reqErr, assertErr error) bool {
+	if p.retry == nil {
+		return false
+	}
+
+	r := p.retry
+	if len(r.OnStatus) == 0 && !r.OnError && !r.UntilAssert {
+		return reqErr != nil || assertErr != nil
+	}
+
+	switch {
+	case reqErr != nil:
+		return r.OnError
+	case slices.Contains(r.OnStatus, statusCode):
+		return true
+	default:
+		return r.UntilAssert && assertErr != nil
+	}
+}
+
+// Delay returns the wait time before the next attempt, attempt is the number of the failed one,
+// exponential backoff without maxDelay grows up to a minute or the base delay when it is longer
+func (p retryPolicy) Delay(attempt int) time.Duration {
+	if p.retry == nil {
+		return 0
+	}
+
+	r := p.retry
+	delay := r.Delay
+	if delay <= 0 {
+		delay = retryDefaultDelay
+	}
+
+	if r.Backoff == retryBackoffExponential {
+		maxDelay := r.MaxDelay
+		if maxDelay <= 0 {
+			maxDelay = max(retryDefaultMaxDelay, delay)
+		}
+
+		for i := 1; i < attempt && delay < maxDelay; i++ {
+			delay *= 2
+		}
+		delay = min(delay, maxDelay)
+	}
+
+	if r.Jitter > 0 {
+		delay += time.Duration(rand.Float64() * r.Jitter * float64(delay))
+	}
+
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+
+	return delay
+}
@@ -1,28 +1,48 @@
 package executors
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/apiqube/cli/internal/core/manifests"
 	"github.com/apiqube/cli/internal/core/manifests/kinds/servers"
+	"github.com/apiqube/cli/internal/core/runner/form"
 	"github.com/apiqube/cli/internal/core/runner/interfaces"
 )
 
-const serverExecutorOutputPrefix = "Server Executor:"
+const (
+	serverExecutorOutputPrefix     = "Server Executor:"
+	serverHealthRequestTimeout     = time.Second * 5
+	serverHealthMaxBodySize        = 1 << 20
+	serverReadinessDefaultInterval = time.Second
+	serverReadinessDefaultTimeout  = time.Second * 30
+)
 
 var _ interfaces.Executor = (*ServerExecutor)(nil)
 
-type ServerExecutor struct{}
+type ServerExecutor struct {
+	passer     *form.Runner
+	tokens     *tokenCache
+	transports *transports
+}
 
 func NewServerExecutor() *ServerExecutor {
-	return &ServerExecutor{}
+	return &ServerExecutor{
+		passer:     form.NewRunner(),
+		tokens:     newTokenCache(),
+		transports: sharedTransports,
+	}
 }
 
 func (e *ServerExecutor) Run(ctx interfaces.ExecutionContext, manifest manifests.Manifest) error {
 	output := ctx.GetOutput()
-	var err error
 
 	select {
 	case <-ctx.Done():
@@ -36,14 +56,12 @@ func (e *ServerExecutor) Run(ctx interfaces.ExecutionContext, manifest manifests
 	}
 
 	if serverMan.Spec.Health != "" {
-		var resp *http.Response
-
-		resp, err = http.Get(serverMan.Spec.BaseURL + "/health")
-		if err != nil || resp.StatusCode >= 400 {
-			output.Logf(interfaces.WarnLevel, "%s server %s (%s) is not responding: %s", serverExecutorOutputPrefix, serverMan.GetName(), serverMan.Spec.BaseURL, err.Error())
+		if err := e.waitReady(ctx, serverMan); err != nil {
+			output.Logf(interfaces.ErrorLevel, "%s server %s (%s) is not ready\nReason: %s", serverExecutorOutputPrefix, serverMan.GetName(), serverMan.Spec.BaseURL, err.Error())
+			return fmt.Errorf("%s server %s is not ready: %w", serverExecutorOutputPrefix, serverMan.GetName(), err)
 		}
 
-		output.Logf(interfaces.InfoLevel, "%s server %s (%s) is responding", serverExecutorOutputPrefix, serverMan.GetName(), serverMan.Spec.Health)
+		output.Logf(interfaces.InfoLevel, "%s server %s (%s) is ready", serverExecutorOutputPrefix, serverMan.GetName(), serverMan.Spec.Health)
 	}
 
 	baseKey := serverMan.GetID()
@@ -58,3 +76,116 @@ func (e *ServerExecutor) Run(ctx interfaces.ExecutionContext, manifest manifests
 
 	return nil
 }
+
+// waitReady polls server health path until it passes readiness checks or timeout is over,
+// health request is sent with server headers, auth and transport the same way test cases are
+func (e *ServerExecutor) waitReady(ctx interfaces.ExecutionContext, server *servers.Server) error {
+	ready := server.Spec.Ready
+	if ready == nil {
+		ready = &servers.Readiness{}
+	}
+
+	interval := ready.Interval
+	if interval <= 0 {
+		interval = serverReadinessDefaultInterval
+	}
+
+	timeout := ready.Timeout
+	if timeout <= 0 {
+		timeout = serverReadinessDefaultTimeout
+	}
+
+	url := buildHttpURL("", server.Spec.BaseURL, server.Spec.Health)
+	if strings.Contains(server.Spec.Health, "://") {
+		url = server.Spec.Health
+	}
+
+	transport := transportConfig{
+		tls:      server.Spec.TLS,
+		source:   server.GetMeta().GetSource(),
+		settings: server.Spec.Transport,
+	}
+
+	client, err := e.transports.Client(transport)
+	if err != nil {
+		return fmt.Errorf("configure transport failed: %w", err)
+	}
+
+	auth := server.Spec.Auth
+	if !sameHost(url, server.Spec.BaseURL) {
+		auth = nil
+	}
+
+	url, headers, _, err := applyAuth(ctx, e.passer, e.tokens, auth, transport, url, e.passer.MapHeaders(ctx, server.Spec.Headers))
+	if err != nil {
+		return fmt.Errorf("apply health check auth failed: %w", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		err = e.checkHealth(waitCtx, client, url, headers, ready)
+		if err == nil {
+			return nil
+		}
+
+		// Keep the reason of the last completed check rather than the one interrupted by timeout
+		if lastErr == nil || waitCtx.Err() == nil {
+			lastErr = err
+		}
+
+		ctx.GetOutput().Logf(interfaces.DebugLevel, "%s server %s health check attempt %d failed: %s", serverExecutorOutputPrefix, server.GetName(), attempt, err.Error())
+
+		select {
+		case <-waitCtx.Done():
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return fmt.Errorf("health check %s did not pass within %s after %d attempts, last error: %w", url, timeout, attempt, lastErr)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (e *ServerExecutor) checkHealth(ctx context.Context, client *http.Client, url string, headers map[string]string, ready *servers.Readiness) error {
+	reqCtx, cancel := context.WithTimeout(ctx, serverHealthRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create health request failed: %w", err)
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, serverHealthMaxBodySize))
+	if err != nil {
+		return fmt.Errorf("read health response failed: %w", err)
+	}
+
+	if len(ready.Status) > 0 {
+		if !slices.Contains(ready.Status, resp.StatusCode) {
+			return fmt.Errorf("unexpected status %d, expected one of %v", resp.StatusCode, ready.Status)
+		}
+	} else if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %d, expected 2xx", resp.StatusCode)
+	}
+
+	if ready.Body != "" && !strings.Contains(string(body), ready.Body) {
+		return errors.New("response body does not contain expected content")
+	}
+
+	return nil
+}
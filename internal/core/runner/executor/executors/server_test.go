@@ -0,0 +1,92 @@
+package executors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/servers"
+	runctx "github.com/apiqube/cli/internal/core/runner/context"
+)
+
+func TestServerExecutorReadiness(t *testing.T) {
+	var checks atomic.Int64
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ready" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if checks.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"up"}`))
+	}))
+	defer backend.Close()
+
+	t.Run("waits until health check passes", func(t *testing.T) {
+		checks.Store(0)
+		server := newServerManifest("backend", manifests.DefaultNamespace, backend.URL, nil)
+		server.Spec.Health = "/ready"
+		server.Spec.Ready = &servers.Readiness{Interval: time.Millisecond, Timeout: time.Second, Body: `"up"`}
+
+		ctx := runctx.NewCtxBuilder().WithManifests(server).Build()
+		require.NoError(t, NewServerExecutor().Run(ctx, server))
+		require.EqualValues(t, 3, checks.Load())
+
+		baseURL, ok := ctx.Get(server.GetID() + ".baseUrl")
+		require.True(t, ok)
+		require.Equal(t, backend.URL, baseURL)
+	})
+
+	t.Run("fails when server is not ready in time", func(t *testing.T) {
+		checks.Store(0)
+		server := newServerManifest("backend", manifests.DefaultNamespace, backend.URL, nil)
+		server.Spec.Health = "/ready"
+		server.Spec.Ready = &servers.Readiness{Interval: time.Millisecond, Timeout: time.Millisecond * 200, Status: []int{http.StatusNoContent}}
+
+		ctx := runctx.NewCtxBuilder().WithManifests(server).Build()
+		err := NewServerExecutor().Run(ctx, server)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected status 200")
+	})
+
+	t.Run("fails when server is down", func(t *testing.T) {
+		server := newServerManifest("down", manifests.DefaultNamespace, "http://127.0.0.1:1", nil)
+		server.Spec.Health = "/health"
+		server.Spec.Ready = &servers.Readiness{Interval: time.Millisecond * 10, Timeout: time.Millisecond * 50}
+
+		ctx := runctx.NewCtxBuilder().WithManifests(server).Build()
+		err := NewServerExecutor().Run(ctx, server)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did not pass within")
+	})
+}
+
+func TestServerExecutorHealthAuth(t *testing.T) {
+	backend := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer health-token" || r.Header.Get("X-Tenant") != "qube" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer backend.Close()
+
+	server := newServerManifest("secured", manifests.DefaultNamespace, backend.URL, map[string]string{"X-Tenant": "qube"})
+	server.Spec.Health = "/health"
+	server.Spec.Ready = &servers.Readiness{Interval: time.Millisecond, Timeout: time.Millisecond * 200}
+	server.Spec.TLS = &kinds.TLS{InsecureSkipVerify: true}
+	server.Spec.Auth = &kinds.Auth{Bearer: &kinds.BearerAuth{Token: "{{ token }}"}}
+
+	ctx := runctx.NewCtxBuilder().WithManifests(server).Build()
+	ctx.Set("token", "health-token")
+	require.NoError(t, NewServerExecutor().Run(ctx, server))
+
+	server.Spec.Auth = &kinds.Auth{Bearer: &kinds.BearerAuth{Token: "{{ Env(QUBE_TEST_UNSET_HEALTH_TOKEN) }}"}}
+	require.ErrorContains(t, NewServerExecutor().Run(ctx, server), "unresolved template")
+}
@@ -0,0 +1,313 @@
+package executors
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds"
+	"github.com/apiqube/cli/internal/core/runner/form"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+const (
+	hmacDefaultHeader = "X-Signature"
+	hmacEncodingB64   = "base64"
+
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat = "20060102T150405Z"
+	sigV4DateFormat = "20060102"
+	sigV4ServiceS3  = "s3"
+)
+
+var (
+	_ RequestSigner = (*hmacSigner)(nil)
+	_ RequestSigner = (*sigV4Signer)(nil)
+)
+
+// RequestSigner signs request right before it is sent, body is the encoded body the request is sent with
+type RequestSigner interface {
+	Sign(req *http.Request, body []byte) error
+}
+
+// SignerFactory builds signer of the custom signing config from its params with resolved templates
+type SignerFactory func(params map[string]string) (RequestSigner, error)
+
+// signers keeps custom signer factories by name, built-in HMAC and SigV4 signers are always available
+type signers struct {
+	mx        sync.RWMutex
+	factories map[string]SignerFactory
+}
+
+func newSigners() *signers {
+	return &signers{factories: make(map[string]SignerFactory)}
+}
+
+func (s *signers) Register(name string, factory SignerFactory) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.factories[name] = factory
+}
+
+// New returns signer of the signing config, custom signer must be registered before the manifest runs
+func (s *signers) New(ctx interfaces.ExecutionContext, passer *form.Runner, signing *kinds.Signing) (RequestSigner, error) {
+	if signing == nil || signing.Custom == nil {
+		return newRequestSigner(ctx, passer, signing)
+	}
+
+	s.mx.RLock()
+	factory, ok := s.factories[signing.Custom.Name]
+	s.mx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("signer %s is not registered", signing.Custom.Name)
+	}
+
+	creds := &credentials{ctx: ctx, passer: passer, scope: "signing"}
+	params := make(map[string]string, len(signing.Custom.Params))
+	for key, value := range signing.Custom.Params {
+		params[key] = creds.Apply(signing.Custom.Name+" param "+key, value)
+	}
+	if creds.err != nil {
+		return nil, creds.err
+	}
+
+	signer, err := factory(params)
+	if err != nil {
+		return nil, fmt.Errorf("create %s signer failed: %w", signing.Custom.Name, err)
+	}
+	return signer, nil
+}
+
+// newRequestSigner resolves templated keys of the signing config and returns the built-in signer,
+// nil is returned when requests are not signed, keys with unresolved templates are reported as error
+func newRequestSigner(ctx interfaces.ExecutionContext, passer *form.Runner, signing *kinds.Signing) (RequestSigner, error) {
+	creds := &credentials{ctx: ctx, passer: passer, scope: "signing"}
+
+	switch {
+	case signing == nil:
+		return nil, nil
+	case signing.HMAC != nil:
+		signer := &hmacSigner{
+			secret:    []byte(creds.Apply("hmac secret", signing.HMAC.Secret)),
+			header:    signing.HMAC.Header,
+			headers:   signing.HMAC.Headers,
+			timestamp: signing.HMAC.Timestamp,
+			base64:    signing.HMAC.Encoding == hmacEncodingB64,
+			now:       time.Now,
+		}
+		if creds.err != nil {
+			return nil, creds.err
+		}
+		if signer.header == "" {
+			signer.header = hmacDefaultHeader
+		}
+		return signer, nil
+	case signing.SigV4 != nil:
+		signer := &sigV4Signer{
+			accessKey:    creds.Apply("sigv4 accessKey", signing.SigV4.AccessKey),
+			secretKey:    creds.Apply("sigv4 secretKey", signing.SigV4.SecretKey),
+			sessionToken: creds.Apply("sigv4 sessionToken", signing.SigV4.SessionToken),
+			region:       creds.Apply("sigv4 region", signing.SigV4.Region),
+			service:      creds.Apply("sigv4 service", signing.SigV4.Service),
+			now:          time.Now,
+		}
+		if creds.err != nil {
+			return nil, creds.err
+		}
+		signer.doubleEncode = signer.service != sigV4ServiceS3 && !signing.SigV4.DisableDoubleEncoding
+		return signer, nil
+	default:
+		return nil, nil
+	}
+}
+
+// hmacSigner signs canonical string of the request:
+//
+//	METHOD\n/path?query\nname:value\n...\nhex(sha256(body))
+//
+// where headers are the timestamp header followed by configured ones in their order
+type hmacSigner struct {
+	secret    []byte
+	header    string
+	headers   []string
+	timestamp string
+	base64    bool
+	now       func() time.Time
+}
+
+func (s *hmacSigner) Sign(req *http.Request, body []byte) error {
+	names := s.headers
+	if s.timestamp != "" {
+		req.Header.Set(s.timestamp, strconv.FormatInt(s.now().Unix(), 10))
+		names = append([]string{s.timestamp}, names...)
+	}
+
+	var canonical strings.Builder
+	canonical.WriteString(req.Method + "\n")
+	canonical.WriteString(req.URL.RequestURI() + "\n")
+	for _, name := range names {
+		canonical.WriteString(strings.ToLower(name) + ":" + canonicalHeaderValue(req, name) + "\n")
+	}
+	canonical.WriteString(hashHex(body))
+
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(canonical.String()))
+
+	if s.base64 {
+		req.Header.Set(s.header, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	} else {
+		req.Header.Set(s.header, hex.EncodeToString(mac.Sum(nil)))
+	}
+	return nil
+}
+
+// sigV4Signer signs host, content type and X-Amz-* headers of the request following AWS Signature Version 4
+type sigV4Signer struct {
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	region       string
+	service      string
+	doubleEncode bool
+	now          func() time.Time
+}
+
+func (s *sigV4Signer) Sign(req *http.Request, body []byte) error {
+	if req.URL.Host == "" && req.Host == "" {
+		return fmt.Errorf("sigv4 signing requires request host")
+	}
+
+	now := s.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(sigV4TimeFormat))
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	values := map[string]string{"host": host}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			values[lower] = canonicalHeaderValue(req, name)
+		}
+	}
+	names := sortedKeys(values)
+
+	var headers strings.Builder
+	for _, name := range names {
+		headers.WriteString(name + ":" + values[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL, s.doubleEncode),
+		canonicalQuery(req.URL.Query()),
+		headers.String(),
+		signedHeaders,
+		hashHex(body),
+	}, "\n")
+
+	date := now.Format(sigV4DateFormat)
+	scope := strings.Join([]string{date, s.region, s.service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{sigV4Algorithm, now.Format(sigV4TimeFormat), scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	for _, part := range []string{s.region, s.service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", sigV4Algorithm, s.accessKey, scope, signedHeaders, signature))
+	return nil
+}
+
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for _, key := range sortedKeys(query) {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEscape(key)+"="+uriEscape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// canonicalURI encodes every path segment, with double encoding the path as sent is encoded once more
+// the way all AWS services except S3 expect, otherwise the decoded path is encoded once
+func canonicalURI(u *url.URL, doubleEncode bool) string {
+	path := u.Path
+	if doubleEncode {
+		path = u.EscapedPath()
+	}
+	if path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// uriEscape percent-encodes every byte except RFC 3986 unreserved characters, as SigV4 requires
+func uriEscape(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var escaped strings.Builder
+	for i := 0; i < len(value); i++ {
+		b := value[i]
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '.', b == '_', b == '~':
+			escaped.WriteByte(b)
+		default:
+			escaped.WriteByte('%')
+			escaped.WriteByte(hexDigits[b>>4])
+			escaped.WriteByte(hexDigits[b&0x0f])
+		}
+	}
+	return escaped.String()
+}
+
+// canonicalHeaderValue joins header values with commas, trimming and collapsing their spaces
+func canonicalHeaderValue(req *http.Request, name string) string {
+	values := req.Header.Values(name)
+	if len(values) == 0 && strings.EqualFold(name, "host") {
+		values = []string{req.Host}
+		if req.Host == "" {
+			values = []string{req.URL.Host}
+		}
+	}
+
+	trimmed := make([]string, len(values))
+	for i, value := range values {
+		trimmed[i] = strings.Join(strings.Fields(value), " ")
+	}
+	return strings.Join(trimmed, ",")
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,271 @@
+package executors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/servers"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/services"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	"github.com/apiqube/cli/internal/core/runner/form"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+const serviceDefaultHost = "http://localhost"
+
+// httpTarget is the base URL, default headers, credentials and transport the test target points to
+type httpTarget struct {
+	baseURL   string
+	headers   map[string]string
+	auth      *kinds.Auth
+	transport transportConfig
+}
+
+// caseOptions are request settings of the test manifest, they take precedence over the target ones
+type caseOptions struct {
+	auth      *kinds.Auth
+	transport transportConfig
+}
+
+// caseRequest is the resolved URL, headers and transport the case request is sent with,
+// token renews OAuth2 bearer token of every request sent
+type caseRequest struct {
+	url       string
+	headers   map[string]string
+	transport transportConfig
+	token     *tokenSource
+}
+
+// resolveCaseRequest builds templated case URL and headers with credentials of the manifest auth,
+// target default headers, auth, TLS and transport settings are applied only to cases relative to the target,
+// manifest auth is also sent to absolute case URLs of the target host but never to other hosts
+func resolveCaseRequest(ctx interfaces.ExecutionContext, passer *form.Runner, tokens *tokenCache, namespace, target string, opts caseOptions, c tests.HttpCase) (caseRequest, error) {
+	t, err := resolveTarget(ctx, namespace, passer.Apply(ctx, target))
+	if err != nil {
+		return caseRequest{}, err
+	}
+
+	query, ok := passer.ApplyValue(ctx, c.Query).(map[string]any)
+	if !ok && c.Query != nil {
+		return caseRequest{}, fmt.Errorf("query of case %s resolved to unsupported value, expected map", c.Name)
+	}
+
+	rawURL, err := withQuery(passer.Apply(ctx, buildHttpURL(c.Url, t.baseURL, c.Endpoint)), query)
+	if err != nil {
+		return caseRequest{}, err
+	}
+
+	headers := c.Headers
+	if c.Url == "" {
+		headers = mergeHeaders(t.headers, c.Headers)
+		if opts.auth == nil {
+			opts.auth = t.auth
+		}
+		if opts.transport.tls == nil {
+			opts.transport.tls, opts.transport.source = t.transport.tls, t.transport.source
+		}
+		if opts.transport.settings == nil {
+			opts.transport.settings = t.transport.settings
+		}
+	} else if !sameHost(rawURL, passer.Apply(ctx, t.baseURL)) {
+		opts.auth = nil
+	}
+
+	req := caseRequest{transport: opts.transport}
+	if req.url, req.headers, req.token, err = applyAuth(ctx, passer, tokens, opts.auth, opts.transport, rawURL, passer.MapHeaders(ctx, headers)); err != nil {
+		return caseRequest{}, err
+	}
+
+	return req, nil
+}
+
+// sameHost reports whether both URLs point to the same host and port, unparsable URLs never match
+func sameHost(rawURL, baseURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Host == "" {
+		return false
+	}
+
+	return strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host)
+}
+
+// withQuery appends encoded query parameters to the URL, list values become repeated keys
+func withQuery(rawURL string, query map[string]any) (string, error) {
+	if len(query) == 0 {
+		return rawURL, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parse url %s failed: %w", rawURL, err)
+	}
+
+	// Query already present in the URL is kept byte for byte, pre-signed URLs and ordered params stay valid
+	extra := make(url.Values, len(query))
+	for key, val := range query {
+		extra[key] = formValues(val)
+	}
+
+	if encoded := extra.Encode(); u.RawQuery == "" {
+		u.RawQuery = encoded
+	} else if encoded != "" {
+		u.RawQuery += "&" + encoded
+	}
+
+	return u.String(), nil
+}
+
+// resolveTarget resolves test target against Server and Service manifests by name or ID,
+// service containers are selected as <service>/<container>, literal URLs are returned as is
+func resolveTarget(ctx interfaces.ExecutionContext, namespace, target string) (httpTarget, error) {
+	if target == "" || strings.Contains(target, "://") {
+		return httpTarget{baseURL: target}, nil
+	}
+
+	if man, err := findTargetManifest(ctx, manifests.ServerKind, namespace, target); err != nil {
+		return httpTarget{}, err
+	} else if server, ok := man.(*servers.Server); ok {
+		return serverTarget(ctx, server), nil
+	}
+
+	serviceRef, containerName, _ := strings.Cut(target, "/")
+	if man, err := findTargetManifest(ctx, manifests.ServiceKind, namespace, serviceRef); err != nil {
+		return httpTarget{}, err
+	} else if service, ok := man.(*services.Service); ok {
+		return serviceTarget(service, containerName)
+	}
+
+	return httpTarget{baseURL: target}, nil
+}
+
+// findTargetManifest looks for manifest of the kind by ID or by name, preferring the test namespace
+func findTargetManifest(ctx interfaces.ExecutionContext, kind, namespace, ref string) (manifests.Manifest, error) {
+	if man, err := ctx.GetManifestByID(ref); err == nil && man != nil && man.GetKind() == kind {
+		return man, nil
+	}
+
+	candidates, err := ctx.GetManifestsByKind(kind)
+	if errors.Is(err, interfaces.ErrManifestNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("look up %s manifests failed: %w", kind, err)
+	}
+
+	var found []manifests.Manifest
+	for _, man := range candidates {
+		if man.GetName() != ref {
+			continue
+		}
+		if man.GetNamespace() == namespace {
+			return man, nil
+		}
+		found = append(found, man)
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("target %s matches %d %s manifests from different namespaces, use manifest ID instead", ref, len(found), kind)
+	}
+}
+
+// serverTarget prefers values registered by ServerExecutor and falls back to the manifest spec
+func serverTarget(ctx interfaces.ExecutionContext, server *servers.Server) httpTarget {
+	id := server.GetID()
+
+	result := httpTarget{
+		baseURL: server.Spec.BaseURL,
+		headers: make(map[string]string, len(server.Spec.Headers)),
+		auth:    server.Spec.Auth,
+		transport: transportConfig{
+			tls:      server.Spec.TLS,
+			source:   server.GetMeta().GetSource(),
+			settings: server.Spec.Transport,
+		},
+	}
+
+	if val, ok := ctx.Get(fmt.Sprintf("%s.baseUrl", id)); ok {
+		result.baseURL = fmt.Sprint(val)
+	}
+
+	for key, val := range server.Spec.Headers {
+		if stored, ok := ctx.Get(fmt.Sprintf("%s.headers.%s", id, key)); ok {
+			val = fmt.Sprint(stored)
+		}
+		result.headers[key] = val
+	}
+
+	return result
+}
+
+// serviceTarget points to the first published port of the named or the first container
+func serviceTarget(service *services.Service, containerName string) (httpTarget, error) {
+	var container *services.Container
+	for i, c := range service.Spec.Containers {
+		if containerName == "" || c.Name == containerName || c.ContainerName == containerName {
+			container = &service.Spec.Containers[i]
+			break
+		}
+	}
+
+	if container == nil {
+		return httpTarget{}, fmt.Errorf("service %s has no %s container", service.GetID(), containerName)
+	}
+
+	if len(container.Ports) == 0 {
+		return httpTarget{}, fmt.Errorf("service %s container %s has no published ports", service.GetID(), container.Name)
+	}
+
+	hostPort, err := publishedHostPort(container.Ports[0])
+	if err != nil {
+		return httpTarget{}, fmt.Errorf("service %s container %s: %w", service.GetID(), container.Name, err)
+	}
+	return httpTarget{baseURL: fmt.Sprintf("%s:%s", serviceDefaultHost, hostPort)}, nil
+}
+
+// publishedHostPort returns host port of compose port mapping: "80", "8080:80", "127.0.0.1:8080:80" or
+// any of them with "/tcp" protocol suffix, single container port is published on the same host port
+func publishedHostPort(mapping string) (string, error) {
+	mapping, _, _ = strings.Cut(mapping, "/")
+
+	parts := strings.Split(mapping, ":")
+	hostPort := parts[0]
+	if len(parts) > 1 {
+		hostPort = parts[len(parts)-2]
+	}
+
+	if hostPort == "" {
+		return "", fmt.Errorf("port mapping %q has no host port", mapping)
+	}
+	return hostPort, nil
+}
+
+// mergeHeaders returns default headers overridden by case headers, names are compared case-insensitively
+func mergeHeaders(defaults, overrides map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return overrides
+	}
+
+	result := make(map[string]string, len(defaults)+len(overrides))
+	for key, val := range defaults {
+		result[http.CanonicalHeaderKey(key)] = val
+	}
+	for key, val := range overrides {
+		result[http.CanonicalHeaderKey(key)] = val
+	}
+
+	return result
+}
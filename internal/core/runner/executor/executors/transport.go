@@ -0,0 +1,202 @@
+package executors
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/goccy/go-json"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// transportConfig is the TLS and transport settings requests to the target are sent with,
+// source is the manifest file TLS paths are relative to
+type transportConfig struct {
+	tls      *kinds.TLS
+	source   string
+	settings *kinds.Transport
+}
+
+// sharedTransports is used by all executors, so a target is reached through the same connection pool
+// by server health checks, tests of every manifest and load tests
+var sharedTransports = newTransports()
+
+// transports keeps client per configuration, clients have no timeout, every request is limited
+// by its own context, so connections are reused between cases and manifests of the target
+type transports struct {
+	mx      sync.Mutex
+	clients map[string]*http.Client
+}
+
+func newTransports() *transports {
+	return &transports{clients: make(map[string]*http.Client)}
+}
+
+// Client returns client shared by requests with the same configuration, it sends requests through
+// the dedicated transport and follows redirects as configured, default transport is used without TLS and settings
+func (t *transports) Client(cfg transportConfig) (*http.Client, error) {
+	dir := ""
+	if cfg.tls != nil {
+		dir = filepath.Dir(cfg.source)
+	}
+
+	data, err := json.Marshal(struct {
+		Dir       string
+		TLS       *kinds.TLS
+		Transport *kinds.Transport
+	}{dir, cfg.tls, cfg.settings})
+	if err != nil {
+		return nil, fmt.Errorf("encode transport config failed: %w", err)
+	}
+	key := string(data)
+
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if client, ok := t.clients[key]; ok {
+		return client, nil
+	}
+
+	client := &http.Client{CheckRedirect: redirectPolicy(cfg.settings)}
+	if cfg.tls != nil || cfg.settings != nil {
+		if client.Transport, err = buildTransport(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	t.clients[key] = client
+	return client, nil
+}
+
+func buildTransport(cfg transportConfig) (*http.Transport, error) {
+	var err error
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.tls != nil {
+		if transport.TLSClientConfig, err = buildTLSConfig(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.settings != nil {
+		if err = applyTransportSettings(transport, cfg.settings); err != nil {
+			return nil, err
+		}
+	}
+
+	return transport, nil
+}
+
+func applyTransportSettings(transport *http.Transport, settings *kinds.Transport) error {
+	if settings.Proxy != "" {
+		proxy, err := url.Parse(settings.Proxy)
+		if err != nil || proxy.Host == "" {
+			return fmt.Errorf("invalid proxy url %s", settings.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if settings.HTTP2 != nil && !*settings.HTTP2 {
+		// Non-nil empty map disables HTTP/2 upgrade over TLS
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	if settings.KeepAlive != nil {
+		transport.DisableKeepAlives = !*settings.KeepAlive
+	}
+
+	if settings.MaxIdleConns > 0 {
+		transport.MaxIdleConns = settings.MaxIdleConns
+	}
+	if settings.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = settings.MaxIdleConnsPerHost
+	}
+	if settings.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = settings.MaxConnsPerHost
+	}
+	if settings.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = settings.IdleConnTimeout
+	}
+
+	return nil
+}
+
+// redirectPolicy returns the last response instead of following redirects when they are disabled,
+// nil policy keeps the client default of 10 redirects
+func redirectPolicy(settings *kinds.Transport) func(*http.Request, []*http.Request) error {
+	switch {
+	case settings == nil:
+		return nil
+	case settings.FollowRedirects != nil && !*settings.FollowRedirects:
+		return func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	case settings.MaxRedirects > 0:
+		limit := settings.MaxRedirects
+		return func(_ *http.Request, via []*http.Request) error {
+			if len(via) > limit {
+				return fmt.Errorf("stopped after %d redirects", limit)
+			}
+			return nil
+		}
+	default:
+		return nil
+	}
+}
+
+func buildTLSConfig(target transportConfig) (*tls.Config, error) {
+	cfg := target.tls
+	config := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if cfg.MinVersion != "" {
+		version, ok := tlsVersions[cfg.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported tls min version %s", cfg.MinVersion)
+		}
+		config.MinVersion = version
+	}
+
+	if cfg.CA != "" {
+		pem, err := os.ReadFile(manifestFilePath(target.source, cfg.CA))
+		if err != nil {
+			return nil, fmt.Errorf("read ca bundle failed: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca bundle %s has no PEM certificates", cfg.CA)
+		}
+		config.RootCAs = pool
+	}
+
+	if cfg.Cert != "" {
+		cert, err := tls.LoadX509KeyPair(manifestFilePath(target.source, cfg.Cert), manifestFilePath(target.source, cfg.Key))
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate failed: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
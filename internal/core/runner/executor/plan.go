@@ -52,11 +52,20 @@ func (r *Runner) Run(ctx interfaces.ExecutionContext, manifest manifests.Manifes
 		return err
 	}
 
-	if p.Spec.Hooks != nil {
-		if err = r.runHooks(ctx, hooks.BeforeRun, p.Spec.Hooks.BeforeRun); err != nil {
-			output.Logf(interfaces.ErrorLevel, "%s plan before start hooks running failed\nReason: %s", planRunnerOutputPrefix, err.Error())
-			return err
+	if r.hooksRunner != nil {
+		hooks.WithRunner(ctx, r.hooksRunner)
+	}
+
+	planScope := hooks.Scope{PlanID: planID}
+
+	if err = r.runHooks(ctx, hooks.BeforeRun, planScope, p.Spec.Hooks.Actions(hooks.BeforeRun)); err != nil {
+		if errors.Is(err, hooks.ErrSkip) {
+			output.Logf(interfaces.WarnLevel, "%s plan %s skipped by before start hooks", planRunnerOutputPrefix, planID)
+			return nil
 		}
+
+		output.Logf(interfaces.ErrorLevel, "%s plan before start hooks running failed\nReason: %s", planRunnerOutputPrefix, err.Error())
+		return err
 	}
 
 	for _, stage := range p.Spec.Stages {
@@ -66,13 +75,17 @@ func (r *Runner) Run(ctx interfaces.ExecutionContext, manifest manifests.Manifes
 		}
 
 		stageName := stage.Name
+		stageScope := hooks.Scope{PlanID: planID, Stage: stageName}
 		output.Logf(interfaces.InfoLevel, "%s %s stage starting...", planRunnerOutputPrefix, stageName)
 
-		if stage.Hooks != nil {
-			if err = r.runHooks(ctx, hooks.BeforeRun, stage.Hooks.BeforeRun); err != nil {
-				output.Logf(interfaces.ErrorLevel, "%s stage %s before start hooks running failed\nReason: %s", planRunnerOutputPrefix, stageName, err.Error())
-				return err
+		if err = r.runHooks(ctx, hooks.BeforeRun, stageScope, stage.Hooks.Actions(hooks.BeforeRun)); err != nil {
+			if errors.Is(err, hooks.ErrSkip) {
+				output.Logf(interfaces.WarnLevel, "%s stage %s skipped by before start hooks", planRunnerOutputPrefix, stageName)
+				continue
 			}
+
+			output.Logf(interfaces.ErrorLevel, "%s stage %s before start hooks running failed\nReason: %s", planRunnerOutputPrefix, stageName, err.Error())
+			return err
 		}
 
 		var execErr error
@@ -87,38 +100,32 @@ func (r *Runner) Run(ctx interfaces.ExecutionContext, manifest manifests.Manifes
 			return err
 		}
 
-		if stage.Hooks != nil {
-			if err = r.runHooks(ctx, hooks.AfterRun, stage.Hooks.AfterRun); err != nil {
-				output.Logf(interfaces.ErrorLevel, "%s stage %s after finish hooks running failed: %s", planRunnerOutputPrefix, stageName, err.Error())
-				return err
-			}
+		if err = r.runHooks(ctx, hooks.AfterRun, stageScope, stage.Hooks.Actions(hooks.AfterRun)); err != nil {
+			output.Logf(interfaces.ErrorLevel, "%s stage %s after finish hooks running failed: %s", planRunnerOutputPrefix, stageName, err.Error())
+			return err
 		}
 
 		if execErr != nil {
 			output.Logf(interfaces.ErrorLevel, "%s stage %s failed\nReason: %s", planRunnerOutputPrefix, stageName, execErr.Error())
 
-			if stage.Hooks != nil {
-				if err = r.runHooks(ctx, hooks.OnFailure, stage.Hooks.OnFailure); err != nil {
-					output.Logf(interfaces.ErrorLevel, "%s stage %s on failure hooks running failed\nReason: %s", planRunnerOutputPrefix, stageName, err.Error())
-					return err
-				}
+			stageScope.Err = execErr
+			if err = r.runHooks(ctx, hooks.OnFailure, stageScope, stage.Hooks.Actions(hooks.OnFailure)); err != nil {
+				output.Logf(interfaces.ErrorLevel, "%s stage %s on failure hooks running failed\nReason: %s", planRunnerOutputPrefix, stageName, err.Error())
+				return err
 			}
 
-			if p.Spec.Hooks != nil {
-				if err = r.runHooks(ctx, hooks.OnFailure, p.Spec.Hooks.OnFailure); err != nil {
-					output.Logf(interfaces.ErrorLevel, "%s plan on failure hooks running failed\nReason: %s", planRunnerOutputPrefix, err.Error())
-					return errors.Join(execErr, err)
-				}
+			planScope.Err = execErr
+			if err = r.runHooks(ctx, hooks.OnFailure, planScope, p.Spec.Hooks.Actions(hooks.OnFailure)); err != nil {
+				output.Logf(interfaces.ErrorLevel, "%s plan on failure hooks running failed\nReason: %s", planRunnerOutputPrefix, err.Error())
+				return errors.Join(execErr, err)
 			}
 
 			return execErr
 		}
 
-		if stage.Hooks != nil {
-			if err = r.runHooks(ctx, hooks.OnSuccess, stage.Hooks.OnSuccess); err != nil {
-				output.Logf(interfaces.ErrorLevel, "%s stage %s on success hooks running failed\nReason: %s", planRunnerOutputPrefix, stageName, err.Error())
-				return err
-			}
+		if err = r.runHooks(ctx, hooks.OnSuccess, stageScope, stage.Hooks.Actions(hooks.OnSuccess)); err != nil {
+			output.Logf(interfaces.ErrorLevel, "%s stage %s on success hooks running failed\nReason: %s", planRunnerOutputPrefix, stageName, err.Error())
+			return err
 		}
 	}
 
@@ -127,16 +134,14 @@ func (r *Runner) Run(ctx interfaces.ExecutionContext, manifest manifests.Manifes
 		return err
 	}
 
-	if p.Spec.Hooks != nil {
-		if err = r.runHooks(ctx, hooks.AfterRun, p.Spec.Hooks.AfterRun); err != nil {
-			output.Logf(interfaces.ErrorLevel, "%s plan after finish hooks running failed\nReason: %s", planRunnerOutputPrefix, err.Error())
-			return err
-		}
+	if err = r.runHooks(ctx, hooks.AfterRun, planScope, p.Spec.Hooks.Actions(hooks.AfterRun)); err != nil {
+		output.Logf(interfaces.ErrorLevel, "%s plan after finish hooks running failed\nReason: %s", planRunnerOutputPrefix, err.Error())
+		return err
+	}
 
-		if err = r.runHooks(ctx, hooks.OnSuccess, p.Spec.Hooks.OnSuccess); err != nil {
-			output.Logf(interfaces.ErrorLevel, "%s plan on success hooks running failed\nReason: %s", planRunnerOutputPrefix, err.Error())
-			return err
-		}
+	if err = r.runHooks(ctx, hooks.OnSuccess, planScope, p.Spec.Hooks.Actions(hooks.OnSuccess)); err != nil {
+		output.Logf(interfaces.ErrorLevel, "%s plan on success hooks running failed\nReason: %s", planRunnerOutputPrefix, err.Error())
+		return err
 	}
 
 	return nil
@@ -167,7 +172,7 @@ func (r *Runner) runManifestsStrict(ctx interfaces.ExecutionContext, manifestIDs
 		output.Logf(interfaces.InfoLevel, "%s running %s manifest using %s executor", planRunnerOutputPrefix, id, man.GetKind())
 
 		if err = exec.Run(ctx, man); err != nil {
-			return fmt.Errorf("manifest %s failed: %s", id, err.Error())
+			return fmt.Errorf("manifest %s failed: %w", id, err)
 		}
 
 		// Save results if required (this would be integrated with the actual executor)
@@ -216,7 +221,7 @@ func (r *Runner) runManifestsParallel(ctx interfaces.ExecutionContext, manifestI
 			output.Logf(interfaces.InfoLevel, "%s running %s manifest using %s executor", planRunnerOutputPrefix, id, man.GetKind())
 
 			if err = exec.Run(ctx, man); err != nil {
-				errChan <- fmt.Errorf("manifest %s failed: %s", id, err.Error())
+				errChan <- fmt.Errorf("manifest %s failed: %w", id, err)
 				return
 			}
 
@@ -245,8 +250,9 @@ func (r *Runner) runManifestsParallel(ctx interfaces.ExecutionContext, manifestI
 	return nil
 }
 
-func (r *Runner) runHooks(ctx interfaces.ExecutionContext, event hooks.HookEvent, actions []hooks.Action) error {
-	if len(actions) == 0 {
+// runHooks runs actions and registered handlers of the event, hooks runner skips events without both of them
+func (r *Runner) runHooks(ctx interfaces.ExecutionContext, event hooks.HookEvent, scope hooks.Scope, actions []hooks.Action) error {
+	if r.hooksRunner == nil {
 		return nil
 	}
 
@@ -254,6 +260,6 @@ func (r *Runner) runHooks(ctx interfaces.ExecutionContext, event hooks.HookEvent
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
-		return r.hooksRunner.RunHooks(ctx, event, actions)
+		return r.hooksRunner.RunHooks(ctx, event, scope, actions)
 	}
 }
@@ -0,0 +1,144 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/plan"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/values"
+	runctx "github.com/apiqube/cli/internal/core/runner/context"
+	"github.com/apiqube/cli/internal/core/runner/depends"
+	"github.com/apiqube/cli/internal/core/runner/hooks"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+type stubExecutor struct {
+	err   error
+	ran   []string
+	hooks hooks.Runner
+}
+
+func (e *stubExecutor) Run(ctx interfaces.ExecutionContext, manifest manifests.Manifest) error {
+	e.ran = append(e.ran, manifest.GetID())
+	e.hooks = hooks.FromContext(ctx, nil)
+	return e.err
+}
+
+func newTestPlan(stages ...plan.Stage) *plan.Plan {
+	p := &plan.Plan{
+		BaseManifest: kinds.BaseManifest{
+			Version:  manifests.V1,
+			Kind:     manifests.PlanKind,
+			Metadata: kinds.Metadata{Name: "test-plan", Namespace: manifests.DefaultNamespace},
+		},
+	}
+	p.Spec.Stages = stages
+	return p
+}
+
+func newTestValues(name string) *values.Values {
+	v := &values.Values{
+		BaseManifest: kinds.BaseManifest{
+			Version:  manifests.V1,
+			Kind:     manifests.ValuesKind,
+			Metadata: kinds.Metadata{Name: name, Namespace: manifests.DefaultNamespace},
+		},
+	}
+	v.Default()
+	return v
+}
+
+func newTestRegistry(exec interfaces.Executor) *DefaultExecutorRegistry {
+	return &DefaultExecutorRegistry{
+		executors: map[string]interfaces.Executor{manifests.ValuesKind: exec},
+	}
+}
+
+func TestRunnerHooksHandlers(t *testing.T) {
+	first, second := newTestValues("first"), newTestValues("second")
+
+	t.Run("handlers receive plan and stage scopes", func(t *testing.T) {
+		hooksRunner := hooks.NewDefaultHooksRunner()
+
+		var calls []string
+		record := func(_ interfaces.ExecutionContext, event hooks.HookEvent, scope hooks.Scope) error {
+			calls = append(calls, fmt.Sprintf("%s %s %s", scope.Level(), scope.Stage, event))
+			return nil
+		}
+		for _, event := range []hooks.HookEvent{hooks.BeforeRun, hooks.AfterRun, hooks.OnSuccess, hooks.OnFailure} {
+			hooksRunner.RegisterHooksHandler(event, record)
+		}
+
+		exec := &stubExecutor{}
+		p := newTestPlan(plan.Stage{Name: "only", Manifests: []string{first.GetID()}})
+		ctx := runctx.NewCtxBuilder().WithManifests(first).Build()
+
+		require.NoError(t, NewRunner(newTestRegistry(exec), hooksRunner, &depends.Result{}).Run(ctx, p))
+		require.Equal(t, []string{
+			"plan  before run",
+			"stage only before run",
+			"stage only after run",
+			"stage only on success",
+			"plan  after run",
+			"plan  on success",
+		}, calls)
+		require.Same(t, hooksRunner, exec.hooks)
+	})
+
+	t.Run("failure handlers receive error", func(t *testing.T) {
+		hooksRunner := hooks.NewDefaultHooksRunner()
+		failure := errors.New("executor failed")
+
+		var scopes []hooks.Scope
+		hooksRunner.RegisterHooksHandler(hooks.OnFailure, func(_ interfaces.ExecutionContext, _ hooks.HookEvent, scope hooks.Scope) error {
+			scopes = append(scopes, scope)
+			return nil
+		})
+
+		exec := &stubExecutor{err: failure}
+		p := newTestPlan(plan.Stage{Name: "failing", Manifests: []string{first.GetID()}})
+		ctx := runctx.NewCtxBuilder().WithManifests(first).Build()
+
+		require.Error(t, NewRunner(newTestRegistry(exec), hooksRunner, &depends.Result{}).Run(ctx, p))
+		require.Len(t, scopes, 2)
+		require.Equal(t, "stage", scopes[0].Level())
+		require.ErrorIs(t, scopes[0].Err, failure)
+		require.Equal(t, "plan", scopes[1].Level())
+		require.ErrorIs(t, scopes[1].Err, failure)
+	})
+
+	t.Run("skip from stage handler skips only that stage", func(t *testing.T) {
+		hooksRunner := hooks.NewDefaultHooksRunner()
+		hooksRunner.RegisterHooksHandler(hooks.BeforeRun, func(_ interfaces.ExecutionContext, _ hooks.HookEvent, scope hooks.Scope) error {
+			if scope.Stage == "skipped" {
+				return hooks.ErrSkip
+			}
+			return nil
+		})
+
+		exec := &stubExecutor{}
+		p := newTestPlan(
+			plan.Stage{Name: "skipped", Manifests: []string{first.GetID()}},
+			plan.Stage{Name: "executed", Manifests: []string{second.GetID()}},
+		)
+		ctx := runctx.NewCtxBuilder().WithManifests(first, second).Build()
+
+		require.NoError(t, NewRunner(newTestRegistry(exec), hooksRunner, &depends.Result{}).Run(ctx, p))
+		require.Equal(t, []string{second.GetID()}, exec.ran)
+	})
+
+	t.Run("plan runs without hooks runner", func(t *testing.T) {
+		exec := &stubExecutor{}
+		p := newTestPlan(plan.Stage{Name: "only", Manifests: []string{first.GetID()}})
+		ctx := runctx.NewCtxBuilder().WithManifests(first).Build()
+
+		require.NoError(t, NewRunner(newTestRegistry(exec), nil, &depends.Result{}).Run(ctx, p))
+		require.Equal(t, []string{first.GetID()}, exec.ran)
+		require.Nil(t, exec.hooks)
+	})
+}
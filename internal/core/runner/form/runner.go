@@ -5,8 +5,6 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/goccy/go-json"
-
 	"github.com/apiqube/cli/internal/core/runner/interfaces"
 	"github.com/apiqube/cli/internal/core/runner/templates"
 )
@@ -16,6 +14,7 @@ type Runner struct {
 	processor         Processor
 	templateResolver  TemplateResolver
 	referenceResolver ReferenceResolver
+	valueExtractor    ValueExtractor
 	templateEngine    *templates.TemplateEngine
 }
 
@@ -38,6 +37,7 @@ func NewRunner() *Runner {
 		processor:         processor,
 		templateResolver:  templateResolver,
 		referenceResolver: referenceResolver,
+		valueExtractor:    valueExtractor,
 		templateEngine:    templateEngine,
 	}
 }
@@ -84,15 +84,21 @@ func (r *Runner) ApplyBody(ctx interfaces.ExecutionContext, body map[string]any)
 		}
 
 		if resolvedMap, is := resolved.(map[string]any); is {
-			if data, err := json.MarshalIndent(resolvedMap, "", "  "); err == nil {
-				fmt.Println(string(data))
-			}
 			return resolvedMap
 		}
 	}
 	return body
 }
 
+// ApplyValue resolves templates in a single value keeping resolved types,
+// a string consisting of one template is replaced with the referenced value itself
+func (r *Runner) ApplyValue(ctx interfaces.ExecutionContext, value any) any {
+	if value == nil {
+		return nil
+	}
+	return r.processor.Process(ctx, value, nil, []int{})
+}
+
 // MapHeaders processes header mappings
 func (r *Runner) MapHeaders(ctx interfaces.ExecutionContext, headers map[string]string) map[string]string {
 	if headers == nil {
@@ -127,10 +133,10 @@ func (r *Runner) applyTemplateResolution(ctx interfaces.ExecutionContext, input
 		default:
 		}
 		key := strings.Trim(match, "{} \t")
-		if val, ok := ctx.Get(key); ok {
+		if val, ok := lookupValue(ctx, r.valueExtractor, key); ok {
 			return fmt.Sprintf("%v", val)
 		}
-		if strings.HasPrefix(key, "Fake.") {
+		if strings.HasPrefix(key, "Fake.") || strings.HasPrefix(key, "Env(") {
 			if val, err := r.templateEngine.Execute(match); err == nil {
 				return fmt.Sprintf("%v", val)
 			}
@@ -132,6 +132,12 @@ func TestRunner_Apply(t *testing.T) {
 	runner := NewRunner()
 	ctx := NewMockExecutionContext()
 	ctx.Set("username", "john")
+	ctx.Set("fetch-user", map[string]any{
+		"response": map[string]any{
+			"status": 200,
+			"body":   map[string]any{"users": []any{map[string]any{"name": "alice"}}},
+		},
+	})
 
 	tests := []struct {
 		name     string
@@ -143,6 +149,16 @@ func TestRunner_Apply(t *testing.T) {
 			input:    "Hello {{ username }}",
 			expected: "Hello john",
 		},
+		{
+			name:     "alias reference",
+			input:    "{{ fetch-user.response.status }} {{ fetch-user.response.body.users.0.name }}",
+			expected: "200 alice",
+		},
+		{
+			name:     "missing alias path",
+			input:    "{{ fetch-user.response.body.missing }}",
+			expected: "{{ fetch-user.response.body.missing }}",
+		},
 		{
 			name:     "no template",
 			input:    "Hello world",
@@ -31,7 +31,7 @@ func (r *DefaultTemplateResolver) Resolve(ctx interfaces.ExecutionContext, templ
 	}
 
 	// Try to get value from context first
-	if val, ok := ctx.Get(content); ok {
+	if val, ok := lookupValue(ctx, r.valueExtractor, content); ok {
 		return val, nil
 	}
 
@@ -61,6 +61,16 @@ func (r *DefaultTemplateResolver) Resolve(ctx interfaces.ExecutionContext, templ
 		return result, nil
 	}
 
+	// Handle Env(...) templates: always wrap in {{ ... }} for template engine
+	if strings.HasPrefix(content, "Env(") {
+		wrapped := "{{ " + content + " }}"
+		result, err := r.templateEngine.Execute(wrapped)
+		if err != nil {
+			return wrapped, err
+		}
+		return result, nil
+	}
+
 	// Use template engine for other cases
 	result, err := r.templateEngine.Execute(templateStr)
 	if err != nil {
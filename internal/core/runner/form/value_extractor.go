@@ -3,6 +3,8 @@ package form
 import (
 	"strconv"
 	"strings"
+
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
 )
 
 // DefaultValueExtractor implements ValueExtractor interface
@@ -57,3 +59,28 @@ func (e *DefaultValueExtractor) Extract(parts []string, data any, indexStack []i
 
 	return current, true
 }
+
+// lookupValue finds value by key in the context store, when the key itself is not stored
+// it takes the longest stored key prefix holding a map or list and extracts the rest of the key from it,
+// so "alias.response.body.id" resolves against the value saved under "alias"
+func lookupValue(ctx interfaces.ExecutionContext, extractor ValueExtractor, key string) (any, bool) {
+	if val, ok := ctx.Get(key); ok {
+		return val, true
+	}
+
+	for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+		val, ok := ctx.Get(key[:i])
+		if !ok {
+			continue
+		}
+
+		switch val.(type) {
+		case map[string]any, []any:
+			return extractor.Extract(strings.Split(key[i+1:], "."), val, nil)
+		default:
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
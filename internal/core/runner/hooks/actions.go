@@ -0,0 +1,280 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+const (
+	ActionLog    = "log"
+	ActionSave   = "save"
+	ActionSkip   = "skip"
+	ActionFail   = "fail"
+	ActionExec   = "exec"
+	ActionNotify = "notify"
+)
+
+const (
+	hooksExecDefaultTimeout   = time.Second * 30
+	hooksNotifyDefaultTimeout = time.Second * 10
+)
+
+// ErrSkip is returned when skip action was triggered, callers decide what exactly must be skipped
+var ErrSkip = errors.New("skipped by hook")
+
+// ErrFail is returned when fail action was triggered
+var ErrFail = errors.New("failed by hook")
+
+type actionHandler func(ctx interfaces.ExecutionContext, event HookEvent, action Action) error
+
+func (r *DefaultHooksRunner) actionHandlers() map[string]actionHandler {
+	return map[string]actionHandler{
+		ActionLog:    r.logAction,
+		ActionSave:   r.saveAction,
+		ActionSkip:   r.skipAction,
+		ActionFail:   r.failAction,
+		ActionExec:   r.execAction,
+		ActionNotify: r.notifyAction,
+	}
+}
+
+// logAction writes templated message with optional level (debug, info, warn, error)
+func (r *DefaultHooksRunner) logAction(ctx interfaces.ExecutionContext, event HookEvent, action Action) error {
+	message, ok := paramString(action.Params, "message")
+	if !ok {
+		return fmt.Errorf("log action requires message param")
+	}
+
+	level := interfaces.InfoLevel
+	if raw, exists := paramString(action.Params, "level"); exists {
+		switch strings.ToLower(raw) {
+		case "debug":
+			level = interfaces.DebugLevel
+		case "info":
+			level = interfaces.InfoLevel
+		case "warn", "warning":
+			level = interfaces.WarnLevel
+		case "error":
+			level = interfaces.ErrorLevel
+		default:
+			return fmt.Errorf("log action has unknown level %s", raw)
+		}
+	}
+
+	ctx.GetOutput().Logf(level, "%s [%s] %s", hooksRunnerOutputPrefix, event.String(), r.passer.Apply(ctx, message))
+	return nil
+}
+
+// saveAction stores templated value into execution context by key
+func (r *DefaultHooksRunner) saveAction(ctx interfaces.ExecutionContext, _ HookEvent, action Action) error {
+	key, ok := paramString(action.Params, "key")
+	if !ok {
+		return fmt.Errorf("save action requires key param")
+	}
+
+	raw, ok := action.Params["value"]
+	if !ok || raw == nil {
+		return fmt.Errorf("save action requires value param")
+	}
+
+	var value any
+	switch v := raw.(type) {
+	case string:
+		value = r.passer.Apply(ctx, v)
+	case map[string]any:
+		value = r.passer.ApplyBody(ctx, v)
+	default:
+		value = v
+	}
+
+	ctx.SetTyped(r.passer.Apply(ctx, key), value, reflect.TypeOf(value).Kind())
+	return nil
+}
+
+// skipAction stops remaining hooks and asks caller to skip the rest of the stage
+func (r *DefaultHooksRunner) skipAction(ctx interfaces.ExecutionContext, _ HookEvent, action Action) error {
+	if reason, ok := paramString(action.Params, "reason"); ok {
+		return fmt.Errorf("%w: %s", ErrSkip, r.passer.Apply(ctx, reason))
+	}
+	return ErrSkip
+}
+
+// failAction aborts execution with templated message
+func (r *DefaultHooksRunner) failAction(ctx interfaces.ExecutionContext, _ HookEvent, action Action) error {
+	if message, ok := paramString(action.Params, "message"); ok {
+		return fmt.Errorf("%w: %s", ErrFail, r.passer.Apply(ctx, message))
+	}
+	return ErrFail
+}
+
+// execAction runs local command with optional args, working dir and timeout, no shell is involved.
+// Without args the command is split on whitespace and quotes are not interpreted, with args list
+// the command is the executable itself and every list item is passed as a single argument,
+// e.g. command: sh, args: ["-c", "echo a b"] when shell features or quoted arguments are needed
+func (r *DefaultHooksRunner) execAction(ctx interfaces.ExecutionContext, event HookEvent, action Action) error {
+	command, ok := paramString(action.Params, "command")
+	if !ok {
+		return fmt.Errorf("exec action requires command param")
+	}
+
+	var (
+		name string
+		args []string
+	)
+
+	if raw, exists := action.Params["args"]; exists {
+		list, is := raw.([]any)
+		if !is {
+			return fmt.Errorf("exec action args param must be a list, got %T", raw)
+		}
+
+		name = strings.TrimSpace(r.passer.Apply(ctx, command))
+		for _, arg := range list {
+			args = append(args, r.passer.Apply(ctx, fmt.Sprint(arg)))
+		}
+	} else if parts := strings.Fields(r.passer.Apply(ctx, command)); len(parts) > 0 {
+		name, args = parts[0], parts[1:]
+	}
+
+	if name == "" {
+		return fmt.Errorf("exec action command is empty")
+	}
+
+	timeout, err := paramDuration(action.Params, "timeout", hooksExecDefaultTimeout)
+	if err != nil {
+		return err
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(execCtx, name, args...)
+	if dir, exists := paramString(action.Params, "dir"); exists {
+		cmd.Dir = dir
+	}
+
+	out, err := cmd.CombinedOutput()
+	if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("exec action %s timed out after %s", command, timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("exec action %s failed: %w\nOutput: %s", command, err, strings.TrimSpace(string(out)))
+	}
+
+	ctx.GetOutput().Logf(interfaces.InfoLevel, "%s [%s] exec %s finished\nOutput: %s", hooksRunnerOutputPrefix, event.String(), command, strings.TrimSpace(string(out)))
+	return nil
+}
+
+// notifyAction sends JSON payload to the target webhook
+func (r *DefaultHooksRunner) notifyAction(ctx interfaces.ExecutionContext, event HookEvent, action Action) error {
+	target, ok := paramString(action.Params, "target")
+	if !ok {
+		return fmt.Errorf("notify action requires target param")
+	}
+	target = r.passer.Apply(ctx, target)
+
+	method := http.MethodPost
+	if m, exists := paramString(action.Params, "method"); exists {
+		method = strings.ToUpper(m)
+	}
+
+	payload := map[string]any{
+		"event":     event.String(),
+		"timestamp": time.Now().Format(time.RFC3339),
+	}
+	if raw, exists := action.Params["payload"]; exists {
+		custom, is := raw.(map[string]any)
+		if !is {
+			return fmt.Errorf("notify action payload param must be a map, got %T", raw)
+		}
+		payload = r.passer.ApplyBody(ctx, custom)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("notify action payload encode failed: %w", err)
+	}
+
+	timeout, err := paramDuration(action.Params, "timeout", hooksNotifyDefaultTimeout)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, method, target, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("notify action request create failed: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if raw, exists := action.Params["headers"]; exists {
+		headers, is := raw.(map[string]any)
+		if !is {
+			return fmt.Errorf("notify action headers param must be a map, got %T", raw)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, r.passer.Apply(ctx, fmt.Sprint(v)))
+		}
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify action request to %s failed: %w", target, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("notify action request to %s failed with status %s", target, resp.Status)
+	}
+
+	ctx.GetOutput().Logf(interfaces.InfoLevel, "%s [%s] notification sent to %s", hooksRunnerOutputPrefix, event.String(), target)
+	return nil
+}
+
+func paramString(params map[string]any, key string) (string, bool) {
+	raw, ok := params[key]
+	if !ok || raw == nil {
+		return "", false
+	}
+
+	if str, is := raw.(string); is {
+		return str, str != ""
+	}
+
+	return fmt.Sprint(raw), true
+}
+
+func paramDuration(params map[string]any, key string, def time.Duration) (time.Duration, error) {
+	raw, ok := params[key]
+	if !ok || raw == nil {
+		return def, nil
+	}
+
+	switch v := raw.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("param %s has wrong duration format %s", key, v)
+		}
+		return d, nil
+	default:
+		return 0, fmt.Errorf("param %s must be duration string, got %T", key, raw)
+	}
+}
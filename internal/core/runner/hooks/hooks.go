@@ -1,16 +1,42 @@
 package hooks
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/apiqube/cli/internal/core/runner/form"
 	"github.com/apiqube/cli/internal/core/runner/interfaces"
 )
 
-const hooksRunnerOutputPrefix = "Hooks Runner:"
+const (
+	hooksRunnerOutputPrefix = "Hooks Runner:"
+
+	// runnerKey keeps hooks runner of the plan in the execution context
+	runnerKey = "__hooks.runner"
+)
 
 type Runner interface {
-	RunHooks(ctx interfaces.ExecutionContext, event HookEvent, actions []Action) error
+	RunHooks(ctx interfaces.ExecutionContext, event HookEvent, scope Scope, actions []Action) error
 	RegisterHooksHandler(event HookEvent, handler HookHandler)
 }
 
+// WithRunner stores runner in the execution context, so plan, stage, manifest and case hooks share its handlers
+func WithRunner(ctx interfaces.ExecutionContext, runner Runner) {
+	ctx.Set(runnerKey, runner)
+}
+
+// FromContext returns runner stored with WithRunner, fallback is returned when manifests run outside a plan
+func FromContext(ctx interfaces.ExecutionContext, fallback Runner) Runner {
+	if val, ok := ctx.Get(runnerKey); ok {
+		if runner, is := val.(Runner); is && runner != nil {
+			return runner
+		}
+	}
+	return fallback
+}
+
 type HookEvent string
 
 func (h HookEvent) String() string {
@@ -22,9 +48,37 @@ const (
 	AfterRun  HookEvent = "after run"
 	OnSuccess HookEvent = "on success"
 	OnFailure HookEvent = "on failure"
+
+	BeforeRequest HookEvent = "before request"
+	AfterResponse HookEvent = "after response"
 )
 
-type HookHandler func(ctx interfaces.ExecutionContext, actions []Action) error
+// Scope identifies plan, stage, manifest or case hooks are running for
+type Scope struct {
+	PlanID     string
+	Stage      string
+	ManifestID string
+	Case       string
+	Err        error // Failure reason, set only for OnFailure event
+}
+
+// Level returns the most specific level of the scope: plan, stage, manifest or case
+func (s Scope) Level() string {
+	switch {
+	case s.Case != "":
+		return "case"
+	case s.ManifestID != "":
+		return "manifest"
+	case s.Stage != "":
+		return "stage"
+	default:
+		return "plan"
+	}
+}
+
+// HookHandler is a Go callback registered with RegisterHooksHandler,
+// it runs after actions declared in manifests for the same event
+type HookHandler func(ctx interfaces.ExecutionContext, event HookEvent, scope Scope) error
 
 type Action struct {
 	Type   string         `yaml:"type" json:"type" validate:"required,oneof=log save skip fail exec notify"` // eg log/save/skip/fail/exec/notify
@@ -32,62 +86,95 @@ type Action struct {
 }
 
 type DefaultHooksRunner struct {
-	entries map[HookEvent][]HookHandler
+	mx       sync.RWMutex
+	entries  map[HookEvent][]HookHandler
+	handlers map[string]actionHandler
+	passer   *form.Runner
+	client   *http.Client
 }
 
 func NewDefaultHooksRunner() *DefaultHooksRunner {
-	return &DefaultHooksRunner{
+	r := &DefaultHooksRunner{
 		entries: make(map[HookEvent][]HookHandler),
+		passer:  form.NewRunner(),
+		client:  &http.Client{},
 	}
+	r.handlers = r.actionHandlers()
+	return r
 }
 
-func (r *DefaultHooksRunner) RunHooks(ctx interfaces.ExecutionContext, event HookEvent, actions []Action) error {
-	if len(actions) == 0 {
+// RunHooks executes actions one by one and then registered handlers, stopping on the first error.
+// Skip returns ErrSkip for BeforeRun and BeforeRequest hooks, so caller can skip the stage or case,
+// for other events it only stops the remaining actions and handlers.
+func (r *DefaultHooksRunner) RunHooks(ctx interfaces.ExecutionContext, event HookEvent, scope Scope, actions []Action) error {
+	r.mx.RLock()
+	handlers := r.entries[event]
+	r.mx.RUnlock()
+
+	if len(actions) == 0 && len(handlers) == 0 {
 		return nil
 	}
 
-	switch event {
-	case BeforeRun:
-		return r.runBeforeRunHooks(ctx, event, actions)
-	case AfterRun:
-		return r.runAfterRunHooks(ctx, event, actions)
-	case OnSuccess:
-		return r.runOnSuccessHooks(ctx, event, actions)
-	case OnFailure:
-		return r.runOnFailureHooks(ctx, event, actions)
-	default:
+	output := ctx.GetOutput()
+	output.Logf(interfaces.InfoLevel, "%s running %s %s hooks", hooksRunnerOutputPrefix, scope.Level(), event.String())
+
+	err := r.runActions(ctx, event, actions)
+	if err == nil {
+		err = r.runHandlers(ctx, event, scope, handlers)
+	}
+
+	if errors.Is(err, ErrSkip) {
+		output.Logf(interfaces.InfoLevel, "%s %s %s hooks stopped: %s", hooksRunnerOutputPrefix, scope.Level(), event.String(), err.Error())
+		if event == BeforeRun || event == BeforeRequest {
+			return err
+		}
 		return nil
 	}
+
+	return err
 }
 
 func (r *DefaultHooksRunner) RegisterHooksHandler(event HookEvent, handler HookHandler) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
 	r.entries[event] = append(r.entries[event], handler)
 }
 
-func (r *DefaultHooksRunner) runBeforeRunHooks(ctx interfaces.ExecutionContext, event HookEvent, _ []Action) error {
-	output := ctx.GetOutput()
-	output.Logf(interfaces.InfoLevel, "%s running %s hooks", hooksRunnerOutputPrefix, event.String())
-
-	return nil
-}
-
-func (r *DefaultHooksRunner) runAfterRunHooks(ctx interfaces.ExecutionContext, event HookEvent, _ []Action) error {
-	output := ctx.GetOutput()
-	output.Logf(interfaces.InfoLevel, "%s running %s hooks", hooksRunnerOutputPrefix, event.String())
-
-	return nil
-}
-
-func (r *DefaultHooksRunner) runOnSuccessHooks(ctx interfaces.ExecutionContext, event HookEvent, _ []Action) error {
-	output := ctx.GetOutput()
-	output.Logf(interfaces.InfoLevel, "%s running %s hooks", hooksRunnerOutputPrefix, event.String())
+func (r *DefaultHooksRunner) runActions(ctx interfaces.ExecutionContext, event HookEvent, actions []Action) error {
+	for _, action := range actions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		handler, ok := r.handlers[action.Type]
+		if !ok {
+			return fmt.Errorf("%s unknown %s hook action type %s", hooksRunnerOutputPrefix, event.String(), action.Type)
+		}
+
+		if err := handler(ctx, event, action); err != nil {
+			if errors.Is(err, ErrSkip) {
+				return err
+			}
+			return fmt.Errorf("%s hook action %s failed: %w", event.String(), action.Type, err)
+		}
+	}
 
 	return nil
 }
 
-func (r *DefaultHooksRunner) runOnFailureHooks(ctx interfaces.ExecutionContext, event HookEvent, _ []Action) error {
-	output := ctx.GetOutput()
-	output.Logf(interfaces.InfoLevel, "%s running %s hooks", hooksRunnerOutputPrefix, event.String())
+func (r *DefaultHooksRunner) runHandlers(ctx interfaces.ExecutionContext, event HookEvent, scope Scope, handlers []HookHandler) error {
+	for _, handler := range handlers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := handler(ctx, event, scope); err != nil {
+			if errors.Is(err, ErrSkip) {
+				return err
+			}
+			return fmt.Errorf("%s hook handler failed: %w", event.String(), err)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,194 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+
+	runctx "github.com/apiqube/cli/internal/core/runner/context"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+func TestDefaultHooksRunnerActions(t *testing.T) {
+	runner := NewDefaultHooksRunner()
+
+	t.Run("log action", func(t *testing.T) {
+		ctx := runctx.NewCtxBuilder().WithValue("user", "alice", reflect.String).Build()
+		require.NoError(t, runner.RunHooks(ctx, BeforeRun, Scope{}, []Action{
+			{Type: ActionLog, Params: map[string]any{"message": "hello {{ user }}", "level": "warn"}},
+		}))
+
+		err := runner.RunHooks(ctx, BeforeRun, Scope{}, []Action{{Type: ActionLog, Params: map[string]any{}}})
+		require.Error(t, err)
+	})
+
+	t.Run("save action", func(t *testing.T) {
+		ctx := runctx.NewCtxBuilder().WithValue("user", "alice", reflect.String).Build()
+		require.NoError(t, runner.RunHooks(ctx, AfterRun, Scope{}, []Action{
+			{Type: ActionSave, Params: map[string]any{"key": "greeting", "value": "hi {{ user }}"}},
+			{Type: ActionSave, Params: map[string]any{"key": "limits", "value": map[string]any{"max": 10}}},
+		}))
+
+		val, ok := ctx.Get("greeting")
+		require.True(t, ok)
+		require.Equal(t, "hi alice", val)
+
+		val, ok = ctx.Get("limits")
+		require.True(t, ok)
+		require.Equal(t, map[string]any{"max": 10}, val)
+	})
+
+	t.Run("skip action", func(t *testing.T) {
+		ctx := runctx.NewCtxBuilder().Build()
+		actions := []Action{
+			{Type: ActionSkip, Params: map[string]any{"reason": "not today"}},
+			{Type: ActionFail, Params: map[string]any{"message": "must not run"}},
+		}
+
+		err := runner.RunHooks(ctx, BeforeRun, Scope{}, actions)
+		require.ErrorIs(t, err, ErrSkip)
+		require.Contains(t, err.Error(), "not today")
+
+		require.NoError(t, runner.RunHooks(ctx, OnSuccess, Scope{}, actions))
+	})
+
+	t.Run("fail action", func(t *testing.T) {
+		ctx := runctx.NewCtxBuilder().WithValue("reason", "broken", reflect.String).Build()
+		err := runner.RunHooks(ctx, OnFailure, Scope{}, []Action{
+			{Type: ActionFail, Params: map[string]any{"message": "stage is {{ reason }}"}},
+		})
+		require.ErrorIs(t, err, ErrFail)
+		require.Contains(t, err.Error(), "stage is broken")
+	})
+
+	t.Run("exec action", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("exec action test uses unix commands")
+		}
+
+		ctx := runctx.NewCtxBuilder().Build()
+		require.NoError(t, runner.RunHooks(ctx, BeforeRun, Scope{}, []Action{
+			{Type: ActionExec, Params: map[string]any{"command": "echo", "args": []any{"done"}}},
+		}))
+
+		require.NoError(t, runner.RunHooks(ctx, BeforeRun, Scope{}, []Action{
+			{Type: ActionExec, Params: map[string]any{"command": "sh", "args": []any{"-c", `test "$1" = "a b"`, "sh", "a b"}}},
+		}), "list args are passed whole")
+
+		err := runner.RunHooks(ctx, BeforeRun, Scope{}, []Action{
+			{Type: ActionExec, Params: map[string]any{"command": "sleep 5", "timeout": "50ms"}},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "timed out")
+
+		err = runner.RunHooks(ctx, BeforeRun, Scope{}, []Action{
+			{Type: ActionExec, Params: map[string]any{"command": "false"}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("notify action", func(t *testing.T) {
+		var received map[string]any
+		var token string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token = r.Header.Get("X-Token")
+			data, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(data, &received); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if received["fail"] == true {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		ctx := runctx.NewCtxBuilder().WithValue("stage", "api", reflect.String).Build()
+		require.NoError(t, runner.RunHooks(ctx, OnFailure, Scope{}, []Action{
+			{Type: ActionNotify, Params: map[string]any{
+				"target":  server.URL,
+				"headers": map[string]any{"X-Token": "secret"},
+				"payload": map[string]any{"text": "{{ stage }} failed"},
+			}},
+		}))
+		require.Equal(t, "secret", token)
+		require.Equal(t, "api failed", received["text"])
+
+		require.NoError(t, runner.RunHooks(ctx, OnSuccess, Scope{}, []Action{
+			{Type: ActionNotify, Params: map[string]any{"target": server.URL}},
+		}))
+		require.Equal(t, OnSuccess.String(), received["event"])
+
+		err := runner.RunHooks(ctx, OnFailure, Scope{}, []Action{
+			{Type: ActionNotify, Params: map[string]any{"target": server.URL, "payload": map[string]any{"fail": true}}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		ctx := runctx.NewCtxBuilder().Build()
+		err := runner.RunHooks(ctx, BeforeRun, Scope{}, []Action{{Type: "unknown"}})
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrSkip))
+	})
+}
+
+func TestDefaultHooksRunnerHandlers(t *testing.T) {
+	t.Run("handlers run after actions with scope", func(t *testing.T) {
+		runner := NewDefaultHooksRunner()
+		failure := errors.New("stage failed")
+
+		var calls []string
+		runner.RegisterHooksHandler(OnFailure, func(ctx interfaces.ExecutionContext, event HookEvent, scope Scope) error {
+			value, _ := ctx.Get("saved")
+			calls = append(calls, fmt.Sprintf("%s:%s:%s:%v:%v", event, scope.Level(), scope.Stage, scope.Err, value))
+			return nil
+		})
+
+		ctx := runctx.NewCtxBuilder().Build()
+		require.NoError(t, runner.RunHooks(ctx, OnFailure, Scope{PlanID: "plan", Stage: "api", Err: failure}, []Action{
+			{Type: ActionSave, Params: map[string]any{"key": "saved", "value": "yes"}},
+		}))
+		require.NoError(t, runner.RunHooks(ctx, OnSuccess, Scope{PlanID: "plan"}, nil))
+
+		require.Equal(t, []string{"on failure:stage:api:stage failed:yes"}, calls)
+	})
+
+	t.Run("handlers run without declared actions", func(t *testing.T) {
+		runner := NewDefaultHooksRunner()
+
+		called := false
+		runner.RegisterHooksHandler(BeforeRun, func(_ interfaces.ExecutionContext, _ HookEvent, scope Scope) error {
+			called = scope.Level() == "plan"
+			return nil
+		})
+
+		require.NoError(t, runner.RunHooks(runctx.NewCtxBuilder().Build(), BeforeRun, Scope{PlanID: "plan"}, nil))
+		require.True(t, called)
+	})
+
+	t.Run("handler errors and skip", func(t *testing.T) {
+		runner := NewDefaultHooksRunner()
+		runner.RegisterHooksHandler(BeforeRun, func(_ interfaces.ExecutionContext, _ HookEvent, _ Scope) error {
+			return ErrSkip
+		})
+		runner.RegisterHooksHandler(AfterRun, func(_ interfaces.ExecutionContext, _ HookEvent, _ Scope) error {
+			return errors.New("boom")
+		})
+
+		ctx := runctx.NewCtxBuilder().Build()
+		require.ErrorIs(t, runner.RunHooks(ctx, BeforeRun, Scope{}, nil), ErrSkip)
+		require.ErrorContains(t, runner.RunHooks(ctx, AfterRun, Scope{}, nil), "boom")
+	})
+}
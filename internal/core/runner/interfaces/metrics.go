@@ -23,4 +23,8 @@ const (
 	AvgAggregation AggregationMethod = "avg"
 	MinAggregation AggregationMethod = "min"
 	MaxAggregation AggregationMethod = "max"
+	P50Aggregation AggregationMethod = "p50"
+	P90Aggregation AggregationMethod = "p90"
+	P95Aggregation AggregationMethod = "p95"
+	P99Aggregation AggregationMethod = "p99"
 )
@@ -13,6 +13,8 @@ type CaseResult struct {
 	Errors     []string
 	Values     map[string]any
 	Details    map[string]any
+	Metrics    []*MetricResult
+	Asserts    []*AssertResult
 }
 
 type Message struct {
@@ -28,9 +30,22 @@ type Progress struct {
 }
 
 type MetricResult struct {
-	Name  string
-	Value float64
-	Unit  string
-	Warn  float64
-	Error float64
+	Name      string
+	Value     float64
+	Unit      string
+	Warn      float64
+	Error     float64
+	Threshold string
+	Failed    bool
+}
+
+// AssertResult is an outcome of a single assertion, soft failures are recorded without failing the case
+type AssertResult struct {
+	Name     string
+	Target   string
+	Expected string
+	Actual   string
+	Passed   bool
+	Soft     bool
+	Error    string
 }
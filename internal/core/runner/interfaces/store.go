@@ -1,11 +1,15 @@
 package interfaces
 
 import (
+	"errors"
 	"reflect"
 
 	"github.com/apiqube/cli/internal/core/manifests"
 )
 
+// ErrManifestNotFound is returned by manifest store when no manifest matches the lookup
+var ErrManifestNotFound = errors.New("no such manifest")
+
 type ManifestStore interface {
 	GetAllManifests() []manifests.Manifest
 	GetManifestsByKind(kind string) ([]manifests.Manifest, error)
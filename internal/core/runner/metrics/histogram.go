@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	// histogramSubBucketBits defines precision of the histogram, 7 bits keeps relative error under 1%
+	histogramSubBucketBits  = 7
+	histogramSubBucketCount = 1 << histogramSubBucketBits
+	histogramSubBucketHalf  = histogramSubBucketCount / 2
+)
+
+// Histogram is an HDR-style histogram with log-linear buckets.
+// Values lower than sub bucket count are stored exactly, higher values
+// are grouped into buckets which width grows with magnitude of the value.
+// Recorded values are integers, so callers choose the unit (e.g. microseconds).
+type Histogram struct {
+	counts []int64
+	total  int64
+	min    int64
+	max    int64
+	sum    float64
+}
+
+// Summary is a snapshot of the most used histogram statistics
+type Summary struct {
+	Count int64
+	Min   float64
+	Max   float64
+	Mean  float64
+	P50   float64
+	P90   float64
+	P95   float64
+	P99   float64
+}
+
+func NewHistogram() *Histogram {
+	return &Histogram{
+		counts: make([]int64, histogramSubBucketCount),
+	}
+}
+
+// Record adds value to the histogram, negative values are recorded as zero
+func (h *Histogram) Record(value int64) {
+	if value < 0 {
+		value = 0
+	}
+
+	idx := histogramIndex(value)
+	if idx >= len(h.counts) {
+		grown := make([]int64, idx+histogramSubBucketHalf)
+		copy(grown, h.counts)
+		h.counts = grown
+	}
+
+	h.counts[idx]++
+
+	if h.total == 0 || value < h.min {
+		h.min = value
+	}
+	if value > h.max {
+		h.max = value
+	}
+
+	h.total++
+	h.sum += float64(value)
+}
+
+func (h *Histogram) Count() int64 {
+	return h.total
+}
+
+func (h *Histogram) Min() float64 {
+	return float64(h.min)
+}
+
+func (h *Histogram) Max() float64 {
+	return float64(h.max)
+}
+
+func (h *Histogram) Sum() float64 {
+	return h.sum
+}
+
+func (h *Histogram) Mean() float64 {
+	if h.total == 0 {
+		return 0
+	}
+	return h.sum / float64(h.total)
+}
+
+// Percentile returns the highest value equivalent to the given percentile (0-100)
+func (h *Histogram) Percentile(p float64) float64 {
+	if h.total == 0 {
+		return 0
+	}
+
+	p = math.Max(0, math.Min(100, p))
+
+	rank := int64(math.Ceil(p / 100 * float64(h.total)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	var seen int64
+	for idx, count := range h.counts {
+		seen += count
+		if seen >= rank {
+			return float64(min(histogramUpperBound(idx), h.max))
+		}
+	}
+
+	return float64(h.max)
+}
+
+func (h *Histogram) Summary() Summary {
+	return Summary{
+		Count: h.total,
+		Min:   h.Min(),
+		Max:   h.Max(),
+		Mean:  h.Mean(),
+		P50:   h.Percentile(50),
+		P90:   h.Percentile(90),
+		P95:   h.Percentile(95),
+		P99:   h.Percentile(99),
+	}
+}
+
+func histogramIndex(value int64) int {
+	if value < histogramSubBucketCount {
+		return int(value)
+	}
+
+	shift := bits.Len64(uint64(value)) - histogramSubBucketBits
+	return shift*histogramSubBucketHalf + int(value>>shift)
+}
+
+func histogramUpperBound(idx int) int64 {
+	if idx < histogramSubBucketCount {
+		return int64(idx)
+	}
+
+	shift := (idx - histogramSubBucketHalf) / histogramSubBucketHalf
+	sub := int64(idx - shift*histogramSubBucketHalf)
+
+	return (sub+1)<<shift - 1
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+// Load metrics recorded by load executors
+const (
+	LoadLatency  = "latency"
+	LoadRequests = "requests"
+	LoadFailures = "failures"
+)
+
+// Load case summary metric names
+const (
+	MetricP50       = "p50"
+	MetricP90       = "p90"
+	MetricP95       = "p95"
+	MetricP99       = "p99"
+	MetricMin       = "min"
+	MetricAvg       = "avg"
+	MetricMax       = "max"
+	MetricRPS       = "rps"
+	MetricErrorRate = "errorRate"
+	MetricRequests  = "requests"
+)
+
+// Metric units
+const (
+	UnitMilliseconds = "ms"
+	UnitRPS          = "req/s"
+	UnitPercent      = "%"
+)
+
+// NewLoadStore creates store with registered load metrics, latency is recorded in microseconds
+func NewLoadStore() *Store {
+	store := NewStore()
+	store.RegisterMetric(LoadLatency, interfaces.HistogramMetric)
+	store.RegisterMetric(LoadRequests, interfaces.CounterMetric)
+	store.RegisterMetric(LoadFailures, interfaces.CounterMetric)
+	return store
+}
+
+// RecordLoadSample records latency and result of a single load request
+func RecordLoadSample(store *Store, latency time.Duration, failed bool) {
+	store.SetMetric(LoadLatency, float64(latency.Microseconds()))
+	store.SetMetric(LoadRequests, 1)
+	if failed {
+		store.SetMetric(LoadFailures, 1)
+	}
+}
+
+// LoadSummary builds latency percentiles, throughput and error rate of a load case
+func LoadSummary(store *Store, elapsed time.Duration) []*interfaces.MetricResult {
+	latency, _ := store.Histogram(LoadLatency)
+	requests, _ := store.GetMetric(LoadRequests)
+	failures, _ := store.GetMetric(LoadFailures)
+
+	toMs := func(us float64) float64 {
+		return us / float64(time.Millisecond/time.Microsecond)
+	}
+
+	var rps, errorRate float64
+	if elapsed > 0 {
+		rps = requests / elapsed.Seconds()
+	}
+	if requests > 0 {
+		errorRate = failures / requests * 100
+	}
+
+	return []*interfaces.MetricResult{
+		{Name: MetricRequests, Value: requests},
+		{Name: MetricRPS, Value: rps, Unit: UnitRPS},
+		{Name: MetricErrorRate, Value: errorRate, Unit: UnitPercent},
+		{Name: MetricMin, Value: toMs(latency.Min), Unit: UnitMilliseconds},
+		{Name: MetricAvg, Value: toMs(latency.Mean), Unit: UnitMilliseconds},
+		{Name: MetricP50, Value: toMs(latency.P50), Unit: UnitMilliseconds},
+		{Name: MetricP90, Value: toMs(latency.P90), Unit: UnitMilliseconds},
+		{Name: MetricP95, Value: toMs(latency.P95), Unit: UnitMilliseconds},
+		{Name: MetricP99, Value: toMs(latency.P99), Unit: UnitMilliseconds},
+		{Name: MetricMax, Value: toMs(latency.Max), Unit: UnitMilliseconds},
+	}
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+var _ interfaces.MetricStore = (*Store)(nil)
+
+// Store keeps gauges, counters and histograms by name.
+// Gauges are overwritten by SetMetric, counters accumulate values
+// and histograms record every value for later aggregation.
+type Store struct {
+	mu         sync.RWMutex
+	types      map[string]interfaces.MetricType
+	values     map[string]float64
+	histograms map[string]*Histogram
+}
+
+func NewStore() *Store {
+	return &Store{
+		types:      make(map[string]interfaces.MetricType),
+		values:     make(map[string]float64),
+		histograms: make(map[string]*Histogram),
+	}
+}
+
+func (s *Store) RegisterMetric(name string, metricType interfaces.MetricType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.register(name, metricType)
+}
+
+// SetMetric updates metric by its type, unregistered metrics are registered as gauges
+func (s *Store) SetMetric(name string, value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metricType, ok := s.types[name]
+	if !ok {
+		metricType = interfaces.GaugeMetric
+		s.register(name, metricType)
+	}
+
+	switch metricType {
+	case interfaces.CounterMetric:
+		s.values[name] += value
+	case interfaces.HistogramMetric:
+		s.histograms[name].Record(int64(value))
+	default:
+		s.values[name] = value
+	}
+}
+
+// GetMetric returns gauge or counter value, for histograms it returns amount of recorded values
+func (s *Store) GetMetric(name string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if h, ok := s.histograms[name]; ok {
+		return float64(h.Count()), true
+	}
+
+	val, ok := s.values[name]
+	return val, ok
+}
+
+func (s *Store) Aggregate(name string, method interfaces.AggregationMethod) (float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	h, ok := s.histograms[name]
+	if !ok {
+		val, exists := s.values[name]
+		if !exists {
+			return 0, fmt.Errorf("metric %s not found", name)
+		}
+
+		switch method {
+		case interfaces.SumAggregation, interfaces.AvgAggregation, interfaces.MinAggregation, interfaces.MaxAggregation:
+			return val, nil
+		default:
+			return 0, fmt.Errorf("aggregation %s is supported only by histogram metrics, %s is %s", method, name, s.types[name])
+		}
+	}
+
+	switch method {
+	case interfaces.SumAggregation:
+		return h.Sum(), nil
+	case interfaces.AvgAggregation:
+		return h.Mean(), nil
+	case interfaces.MinAggregation:
+		return h.Min(), nil
+	case interfaces.MaxAggregation:
+		return h.Max(), nil
+	case interfaces.P50Aggregation:
+		return h.Percentile(50), nil
+	case interfaces.P90Aggregation:
+		return h.Percentile(90), nil
+	case interfaces.P95Aggregation:
+		return h.Percentile(95), nil
+	case interfaces.P99Aggregation:
+		return h.Percentile(99), nil
+	default:
+		return 0, fmt.Errorf("unknown aggregation method %s", method)
+	}
+}
+
+// AllMetrics returns every metric value, histograms are expanded into <name>.<aggregation> entries
+func (s *Store) AllMetrics() map[string]float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := make(map[string]float64, len(s.values)+len(s.histograms)*8)
+	for name, val := range s.values {
+		all[name] = val
+	}
+
+	for name, h := range s.histograms {
+		summary := h.Summary()
+		all[name+".count"] = float64(summary.Count)
+		all[name+".min"] = summary.Min
+		all[name+".avg"] = summary.Mean
+		all[name+".max"] = summary.Max
+		all[name+".p50"] = summary.P50
+		all[name+".p90"] = summary.P90
+		all[name+".p95"] = summary.P95
+		all[name+".p99"] = summary.P99
+	}
+
+	return all
+}
+
+// Histogram returns summary of the histogram metric
+func (s *Store) Histogram(name string) (Summary, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	h, ok := s.histograms[name]
+	if !ok {
+		return Summary{}, false
+	}
+
+	return h.Summary(), true
+}
+
+func (s *Store) register(name string, metricType interfaces.MetricType) {
+	if _, ok := s.types[name]; ok {
+		return
+	}
+
+	s.types[name] = metricType
+	if metricType == interfaces.HistogramMetric {
+		s.histograms[name] = NewHistogram()
+	}
+}
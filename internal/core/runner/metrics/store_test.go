@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Run("exact values below sub bucket count", func(t *testing.T) {
+		h := NewHistogram()
+		for i := int64(1); i <= 100; i++ {
+			h.Record(i)
+		}
+
+		require.EqualValues(t, 100, h.Count())
+		require.Equal(t, float64(1), h.Min())
+		require.Equal(t, float64(100), h.Max())
+		require.Equal(t, 50.5, h.Mean())
+		require.Equal(t, float64(50), h.Percentile(50))
+		require.Equal(t, float64(99), h.Percentile(99))
+		require.Equal(t, float64(100), h.Percentile(100))
+	})
+
+	t.Run("large values keep relative precision", func(t *testing.T) {
+		h := NewHistogram()
+		for i := int64(1); i <= 10000; i++ {
+			h.Record(i * 1000)
+		}
+
+		for _, p := range []float64{50, 90, 95, 99} {
+			expected := p / 100 * 10000 * 1000
+			require.InEpsilon(t, expected, h.Percentile(p), 0.01)
+		}
+		require.Equal(t, float64(10000*1000), h.Percentile(100))
+	})
+
+	t.Run("empty histogram", func(t *testing.T) {
+		h := NewHistogram()
+		require.Zero(t, h.Percentile(99))
+		require.Zero(t, h.Mean())
+	})
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore()
+	store.RegisterMetric("requests", interfaces.CounterMetric)
+	store.RegisterMetric("latency", interfaces.HistogramMetric)
+
+	store.SetMetric("requests", 1)
+	store.SetMetric("requests", 2)
+	store.SetMetric("agents", 5)
+	store.SetMetric("agents", 3)
+
+	for _, v := range []float64{10, 20, 30, 40} {
+		store.SetMetric("latency", v)
+	}
+
+	requests, ok := store.GetMetric("requests")
+	require.True(t, ok)
+	require.Equal(t, float64(3), requests)
+
+	agents, ok := store.GetMetric("agents")
+	require.True(t, ok)
+	require.Equal(t, float64(3), agents)
+
+	avg, err := store.Aggregate("latency", interfaces.AvgAggregation)
+	require.NoError(t, err)
+	require.Equal(t, float64(25), avg)
+
+	p50, err := store.Aggregate("latency", interfaces.P50Aggregation)
+	require.NoError(t, err)
+	require.Equal(t, float64(20), p50)
+
+	_, err = store.Aggregate("requests", interfaces.P99Aggregation)
+	require.Error(t, err)
+
+	_, err = store.Aggregate("unknown", interfaces.SumAggregation)
+	require.Error(t, err)
+
+	all := store.AllMetrics()
+	require.Equal(t, float64(40), all["latency.max"])
+	require.Equal(t, float64(4), all["latency.count"])
+}
+
+func TestLoadSummary(t *testing.T) {
+	store := NewLoadStore()
+	for i := 1; i <= 100; i++ {
+		RecordLoadSample(store, time.Duration(i)*time.Millisecond, i%10 == 0)
+	}
+
+	summary := make(map[string]float64)
+	for _, m := range LoadSummary(store, time.Second*10) {
+		summary[m.Name] = m.Value
+	}
+
+	require.Equal(t, float64(100), summary[MetricRequests])
+	require.Equal(t, float64(10), summary[MetricRPS])
+	require.Equal(t, float64(10), summary[MetricErrorRate])
+	require.InEpsilon(t, 95, summary[MetricP95], 0.01)
+	require.InEpsilon(t, 100, summary[MetricMax], 0.01)
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+var thresholdRe = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9]*)\s*(<=|>=|==|!=|<|>)\s*(\S+)\s*$`)
+
+// Threshold is a parsed pass/fail criteria like "p95 < 300ms" or "errorRate < 1%"
+type Threshold struct {
+	Expression string
+	Metric     string
+	Operator   string
+	Value      float64
+	Unit       string
+}
+
+// ParseThreshold parses threshold expression, durations are converted to milliseconds
+func ParseThreshold(expr string) (*Threshold, error) {
+	matches := thresholdRe.FindStringSubmatch(expr)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid threshold %q, expected format: <metric> <operator> <value>", expr)
+	}
+
+	t := &Threshold{
+		Expression: strings.TrimSpace(expr),
+		Metric:     matches[1],
+		Operator:   matches[2],
+	}
+
+	raw := matches[3]
+	switch {
+	case strings.HasSuffix(raw, UnitPercent):
+		val, err := strconv.ParseFloat(strings.TrimSuffix(raw, UnitPercent), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold %q percent value: %s", expr, err.Error())
+		}
+		t.Value, t.Unit = val, UnitPercent
+	default:
+		if val, err := strconv.ParseFloat(raw, 64); err == nil {
+			t.Value = val
+			break
+		}
+
+		dur, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold %q value %s, expected number, percent or duration", expr, raw)
+		}
+		t.Value, t.Unit = float64(dur)/float64(time.Millisecond), UnitMilliseconds
+	}
+
+	return t, nil
+}
+
+// Check reports whether actual value satisfies the threshold
+func (t *Threshold) Check(actual float64) bool {
+	switch t.Operator {
+	case "<":
+		return actual < t.Value
+	case "<=":
+		return actual <= t.Value
+	case ">":
+		return actual > t.Value
+	case ">=":
+		return actual >= t.Value
+	case "==":
+		return actual == t.Value
+	case "!=":
+		return actual != t.Value
+	default:
+		return false
+	}
+}
+
+// EvaluateThresholds checks every threshold expression against metric results,
+// marks checked metrics and returns all violations joined into one error
+func EvaluateThresholds(expressions []string, results []*interfaces.MetricResult) error {
+	byName := make(map[string]*interfaces.MetricResult, len(results))
+	for _, r := range results {
+		byName[r.Name] = r
+	}
+
+	var rErr error
+	for _, expr := range expressions {
+		t, err := ParseThreshold(expr)
+		if err != nil {
+			rErr = errors.Join(rErr, err)
+			continue
+		}
+
+		metric, ok := byName[t.Metric]
+		if !ok {
+			rErr = errors.Join(rErr, fmt.Errorf("threshold %q refers to unknown metric %s", t.Expression, t.Metric))
+			continue
+		}
+
+		if t.Unit != "" && metric.Unit != t.Unit {
+			rErr = errors.Join(rErr, fmt.Errorf("threshold %q unit %s does not match %s metric unit %s", t.Expression, t.Unit, metric.Name, metric.Unit))
+			continue
+		}
+
+		metric.Threshold = t.Expression
+		metric.Error = t.Value
+
+		if !t.Check(metric.Value) {
+			metric.Failed = true
+			rErr = errors.Join(rErr, fmt.Errorf("threshold %q violated, actual %s is %s", t.Expression, metric.Name, strings.TrimSpace(fmt.Sprintf("%.2f %s", metric.Value, metric.Unit))))
+		}
+	}
+
+	return rErr
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		expr     string
+		metric   string
+		operator string
+		value    float64
+		unit     string
+		wantErr  bool
+	}{
+		{expr: "p95 < 300ms", metric: "p95", operator: "<", value: 300, unit: UnitMilliseconds},
+		{expr: "p99<=1.5s", metric: "p99", operator: "<=", value: 1500, unit: UnitMilliseconds},
+		{expr: "errorRate < 1%", metric: "errorRate", operator: "<", value: 1, unit: UnitPercent},
+		{expr: "rps >= 500", metric: "rps", operator: ">=", value: 500},
+		{expr: "p95 300ms", wantErr: true},
+		{expr: "p95 < fast", wantErr: true},
+		{expr: "errorRate < x%", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			threshold, err := ParseThreshold(tt.expr)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.metric, threshold.Metric)
+			require.Equal(t, tt.operator, threshold.Operator)
+			require.Equal(t, tt.value, threshold.Value)
+			require.Equal(t, tt.unit, threshold.Unit)
+		})
+	}
+}
+
+func TestEvaluateThresholds(t *testing.T) {
+	newResults := func() []*interfaces.MetricResult {
+		return []*interfaces.MetricResult{
+			{Name: MetricP95, Value: 250, Unit: UnitMilliseconds},
+			{Name: MetricErrorRate, Value: 2, Unit: UnitPercent},
+			{Name: MetricRPS, Value: 600, Unit: UnitRPS},
+		}
+	}
+
+	t.Run("all thresholds passed", func(t *testing.T) {
+		results := newResults()
+		require.NoError(t, EvaluateThresholds([]string{"p95 < 300ms", "rps >= 500", "errorRate <= 2%"}, results))
+		require.Equal(t, "p95 < 300ms", results[0].Threshold)
+		require.False(t, results[0].Failed)
+	})
+
+	t.Run("violated threshold marks metric", func(t *testing.T) {
+		results := newResults()
+		err := EvaluateThresholds([]string{"p95 < 200ms", "errorRate < 1%"}, results)
+		require.Error(t, err)
+		require.True(t, results[0].Failed)
+		require.True(t, results[1].Failed)
+		require.Equal(t, float64(200), results[0].Error)
+	})
+
+	t.Run("unknown metric and unit mismatch", func(t *testing.T) {
+		require.Error(t, EvaluateThresholds([]string{"p42 < 1s"}, newResults()))
+		require.Error(t, EvaluateThresholds([]string{"rps > 1s"}, newResults()))
+	})
+}
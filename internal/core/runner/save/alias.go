@@ -0,0 +1,67 @@
+package save
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/goccy/go-json"
+
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests"
+	"github.com/apiqube/cli/internal/core/runner/interfaces"
+)
+
+// SaveAlias stores request and response of the aliased case under its alias,
+// so later cases can refer to them as {{ alias.response.body.path }}
+func (e *Extractor) SaveAlias(ctx interfaces.ExecutionContext, c tests.HttpCase, resp *http.Response, reqBody, respBody []byte) {
+	if c.Alias == nil || *c.Alias == "" || resp == nil {
+		return
+	}
+
+	request := map[string]any{"body": decodeBody(reqBody)}
+	if resp.Request != nil {
+		request["method"] = resp.Request.Method
+		request["url"] = resp.Request.URL.String()
+		request["headers"] = flattenHeaders(resp.Request.Header)
+	}
+
+	ctx.Set(*c.Alias, map[string]any{
+		"request": request,
+		"response": map[string]any{
+			"status":  resp.StatusCode,
+			"headers": flattenHeaders(resp.Header),
+			"body":    decodeBody(respBody),
+		},
+	})
+}
+
+func flattenHeaders(header http.Header) map[string]any {
+	result := make(map[string]any, len(header))
+	for key, values := range header {
+		if len(values) == 1 {
+			result[key] = values[0]
+			continue
+		}
+
+		list := make([]any, len(values))
+		for i, v := range values {
+			list[i] = v
+		}
+		result[key] = list
+	}
+	return result
+}
+
+// decodeBody decodes JSON body keeping numbers as is, non JSON bodies are returned as strings
+func decodeBody(body []byte) any {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+	return value
+}
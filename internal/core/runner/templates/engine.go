@@ -47,6 +47,7 @@ func New() *TemplateEngine {
 	e.RegisterFunc("Fake.country", fakeCountry)
 	e.RegisterFunc("Fake.city", fakeCity)
 	e.RegisterFunc("Regex", regex)
+	e.RegisterFunc("Env", env)
 	// Register built-in methods
 	e.RegisterMethod("ToString", methodToString)
 	e.RegisterMethod("ToUpper", methodToUpper)
@@ -64,6 +64,31 @@ func TestTemplateEngine_RegexGenerator(t *testing.T) {
 	}
 }
 
+func TestTemplateEngine_EnvGenerator(t *testing.T) {
+	e := New()
+	t.Setenv("QUBE_TEST_TOKEN", "s3cr3t")
+
+	res, err := e.Execute("{{ Env(QUBE_TEST_TOKEN) }}")
+	if err != nil {
+		t.Fatalf("Env: unexpected error: %v", err)
+	}
+	if res != "s3cr3t" {
+		t.Errorf("Env: expected 's3cr3t', got '%v'", res)
+	}
+
+	res, err = e.Execute("Bearer {{ Env('QUBE_TEST_TOKEN').ToUpper() }}")
+	if err != nil {
+		t.Fatalf("Env: unexpected error: %v", err)
+	}
+	if res != "Bearer S3CR3T" {
+		t.Errorf("Env: expected 'Bearer S3CR3T', got '%v'", res)
+	}
+
+	if _, err = e.Execute("{{ Env(QUBE_TEST_MISSING) }}"); err == nil {
+		t.Error("Env: expected error for missing variable")
+	}
+}
+
 // --- METHODS ---
 func TestTemplateEngine_Methods(t *testing.T) {
 	e := New()
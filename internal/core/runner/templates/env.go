@@ -0,0 +1,21 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+func env(args ...string) (any, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("please provide an environment variable name")
+	}
+
+	name := strings.Trim(strings.TrimSpace(args[0]), `"'`)
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	return value, nil
+}
@@ -29,6 +29,8 @@ type CaseReport struct {
 	Errors     []string
 	Details    map[string]any
 	Values     map[string]any
+	Metrics    []*interfaces.MetricResult
+	Asserts    []*interfaces.AssertResult
 	Request    *save.Entry
 	Response   *save.Entry
 }
@@ -112,6 +114,8 @@ func buildReportViewData(ctx interfaces.ExecutionContext) *ViewData {
 			Response:   res.Response,
 			Details:    cr.Details,
 			Values:     cr.Values,
+			Metrics:    cr.Metrics,
+			Asserts:    cr.Asserts,
 		}
 
 		report.Cases = append(report.Cases, caseReport)
@@ -175,7 +179,8 @@ func NewHTMLReportGenerator() (*ReportGenerator, error) {
 			}
 			return a / b
 		},
-		"mul": func(a, b float64) float64 { return a * b },
+		"mul":          func(a, b float64) float64 { return a * b },
+		"formatMetric": func(v float64) string { return fmt.Sprintf("%.2f", v) },
 		"prettyJSON": func(v any) string {
 			data, err := json.MarshalIndent(v, "", "  ")
 			if err != nil {
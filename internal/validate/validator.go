@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests/load"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -20,6 +21,8 @@ func NewValidator() Validator {
 		_ = v.RegisterValidation(name, validFunc)
 	}
 
+	v.RegisterStructValidation(loadCaseValidationFunc, load.HttpCase{})
+
 	return &baseValidator{
 		validate: v,
 	}
@@ -109,6 +112,12 @@ func buildErrorMessage(fieldErr validator.FieldError) string {
 		return fmt.Sprintf("field '%s' must be equal to %s", fieldName, fieldErr.Param())
 	case "duration":
 		return fmt.Sprintf("field '%s' has wrong format '%s' must be duration", fieldName, fieldErr.Value())
+	case "threshold":
+		return fmt.Sprintf("field '%s' has wrong format '%s' must be threshold like 'p95 < 300ms'", fieldName, fieldErr.Value())
+	case "no_snapshot":
+		return fmt.Sprintf("field '%s' must not contain snapshot target, snapshots are not supported in load tests", fieldName)
+	case "bytesize":
+		return fmt.Sprintf("field '%s' has wrong format '%s' must be size like '512KB' or '1MB'", fieldName, fieldErr.Value())
 	default:
 		return fmt.Sprintf("field '%s' failed validation '%s'", fieldName, fieldErr.Tag())
 	}
@@ -95,9 +95,13 @@ var (
 			},
 		},
 		Spec: struct {
-			BaseURL string            `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
-			Health  string            `yaml:"health" json:"health" validate:"omitempty,max=100"`
-			Headers map[string]string `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+			BaseURL   string             `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
+			Health    string             `yaml:"health" json:"health" validate:"omitempty,max=100"`
+			Headers   map[string]string  `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+			Ready     *servers.Readiness `yaml:"readiness,omitempty" json:"readiness,omitempty" validate:"omitempty"`
+			Auth      *kinds.Auth        `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+			TLS       *kinds.TLS         `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+			Transport *kinds.Transport   `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 		}{
 			BaseURL: "",
 			Health:  "",
@@ -114,8 +118,14 @@ var (
 			},
 		},
 		Spec: struct {
-			Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-			Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+			Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+			CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+			Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+			Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+			TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+			Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 		}{
 			Target: "",
 			Cases:  []api.HttpCase{},
@@ -131,8 +141,14 @@ var (
 			},
 		},
 		Spec: struct {
-			Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-			Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+			Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+			CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+			Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+			Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+			TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+			Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 		}{
 			Target: "target",
 			Cases: []api.HttpCase{
@@ -155,8 +171,9 @@ var (
 			},
 		},
 		Spec: struct {
-			Target string          `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-			Cases  []load.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Target string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+			Cases  []load.HttpCase  `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Hooks  *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
 		}{
 			Target: "",
 			Cases:  []load.HttpCase{},
@@ -172,8 +189,9 @@ var (
 			},
 		},
 		Spec: struct {
-			Target string          `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-			Cases  []load.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Target string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+			Cases  []load.HttpCase  `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Hooks  *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
 		}{
 			Target: "target",
 			Cases: []load.HttpCase{
@@ -247,9 +265,13 @@ var (
 			},
 		},
 		Spec: struct {
-			BaseURL string            `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
-			Health  string            `yaml:"health" json:"health" validate:"omitempty,max=100"`
-			Headers map[string]string `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+			BaseURL   string             `yaml:"baseUrl" json:"baseUrl" validate:"required,url"`
+			Health    string             `yaml:"health" json:"health" validate:"omitempty,max=100"`
+			Headers   map[string]string  `yaml:"headers,omitempty" json:"headers" validate:"omitempty,max=20"`
+			Ready     *servers.Readiness `yaml:"readiness,omitempty" json:"readiness,omitempty" validate:"omitempty"`
+			Auth      *kinds.Auth        `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+			TLS       *kinds.TLS         `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+			Transport *kinds.Transport   `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 		}{
 			BaseURL: "http://127.0.0.1:8080",
 			Health:  "",
@@ -265,8 +287,14 @@ var (
 			},
 		},
 		Spec: struct {
-			Target string         `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-			Cases  []api.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Target    string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+			Cases     []api.HttpCase   `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Hooks     *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
+			CookieJar string           `yaml:"cookieJar,omitempty" json:"cookieJar,omitempty" validate:"omitempty,oneof=manifest plan"`
+			Auth      *kinds.Auth      `yaml:"auth,omitempty" json:"auth,omitempty" validate:"omitempty"`
+			Signing   *kinds.Signing   `yaml:"signing,omitempty" json:"signing,omitempty" validate:"omitempty"`
+			TLS       *kinds.TLS       `yaml:"tls,omitempty" json:"tls,omitempty" validate:"omitempty"`
+			Transport *kinds.Transport `yaml:"transport,omitempty" json:"transport,omitempty" validate:"omitempty"`
 		}{
 			Target: "target",
 			Cases: []api.HttpCase{
@@ -320,8 +348,9 @@ var (
 			},
 		},
 		Spec: struct {
-			Target string          `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
-			Cases  []load.HttpCase `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Target string           `yaml:"target,omitempty" json:"target,omitempty" validate:"required"`
+			Cases  []load.HttpCase  `yaml:"cases" json:"cases" validate:"required,min=1,max=100,dive"`
+			Hooks  *tests.HttpHooks `yaml:"hooks,omitempty" json:"hooks,omitempty" validate:"omitempty"`
 		}{
 			Target: "target",
 			Cases: []load.HttpCase{
@@ -428,6 +457,18 @@ func TestValidator_Validate(t *testing.T) {
 		require.Contains(t, err.Error(), "saveentry")
 	})
 
+	t.Run("InvalidHttpLoadTestCaseManifest: snapshot assert in load case", func(t *testing.T) {
+		man := *validHttpLoadTestCaseManifest
+		c := man.Spec.Cases[0]
+		c.Assert = []*tests.Assert{{Target: "status", Equals: 200}, {Target: "snapshot"}}
+		man.Spec.Cases = []load.HttpCase{c}
+
+		err = v.Validate(&man)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "snapshot")
+		require.Contains(t, err.Error(), "assert")
+	})
+
 	// VALID
 	t.Run("ValidPlanManifest", func(t *testing.T) {
 		err = v.Validate(validPlanManifest)
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/apiqube/cli/internal/core/manifests"
+	"github.com/apiqube/cli/internal/core/manifests/kinds/tests/load"
+	"github.com/apiqube/cli/internal/core/runner/assert"
+	"github.com/apiqube/cli/internal/core/runner/metrics"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -19,6 +22,14 @@ var (
 			value := fl.Field().String()
 			return strings.Contains(value, "{") && strings.Contains(value, "}")
 		},
+		"threshold": func(fl validator.FieldLevel) bool {
+			_, err := metrics.ParseThreshold(fl.Field().String())
+			return err == nil
+		},
+		"bytesize": func(fl validator.FieldLevel) bool {
+			_, err := assert.ParseSize(fl.Field().String())
+			return err == nil
+		},
 	}
 
 	manifestKinsValidationFuncs = map[string]func(fl validator.FieldLevel) bool{
@@ -26,6 +37,21 @@ var (
 	}
 )
 
+// loadCaseValidationFunc rejects snapshot asserts of load test cases, responses of many agents have no single snapshot
+func loadCaseValidationFunc(sl validator.StructLevel) {
+	c, ok := sl.Current().Interface().(load.HttpCase)
+	if !ok {
+		return
+	}
+
+	for _, a := range c.Assert {
+		if a != nil && a.Target == assert.Snapshot.String() {
+			sl.ReportError(a.Target, "Assert", "Assert", "no_snapshot", "")
+			return
+		}
+	}
+}
+
 func planValidationFunc(fl validator.FieldLevel) bool {
 	params, ok := fl.Field().Interface().(map[string]any)
 	if !ok {